@@ -0,0 +1,123 @@
+package main
+
+import (
+	"FoodStore-AdvProg2/infrastructure/config"
+	"FoodStore-AdvProg2/infrastructure/grpc"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/server"
+	"FoodStore-AdvProg2/server/gateway"
+	"FoodStore-AdvProg2/server/inventory"
+	"FoodStore-AdvProg2/server/orders"
+	"FoodStore-AdvProg2/server/users"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	grpcpkg "google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const usage = `usage: foodstore serve <service>
+
+services:
+  gateway    HTTP API gateway, calling the services at their configured URLs
+  orders     order and cart gRPC service
+  inventory  product and category gRPC service
+  users      user and token gRPC service
+  all        the three gRPC services in-process behind the gateway, sharing
+             one database pool; for local development and demos`
+
+func main() {
+	flag.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) != 2 || args[0] != "serve" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	serve, ok := map[string]func(context.Context, *config.Config) error{
+		"gateway":   gateway.Serve,
+		"orders":    orders.Serve,
+		"inventory": inventory.Serve,
+		"users":     users.Serve,
+		"all":       serveAll,
+	}[args[1]]
+	if !ok {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	if err := serve(server.SignalContext(), cfg); err != nil {
+		log.Fatalf("%s stopped: %v", args[1], err)
+	}
+	log.Printf("%s stopped", args[1])
+}
+
+// serveAll registers the order, inventory and user services on one gRPC
+// server that listens in memory. The order service and the gateway reach it
+// through a single connection, so only the gateway's port is opened.
+//
+// On shutdown the gateway drains first, while the gRPC services still answer
+// the calls its requests make, then the gRPC server and background workers
+// stop and the connection and database pool are closed.
+func serveAll(ctx context.Context, cfg *config.Config) error {
+	if err := server.OpenSharedDatabase(cfg); err != nil {
+		return err
+	}
+	defer server.CloseDatabase()
+
+	listener := bufconn.Listen(1 << 20)
+	conn, err := grpcpkg.Dial("bufnet", append(grpc.DialOptions(),
+		grpcpkg.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to in-process services: %w", err)
+	}
+	defer conn.Close()
+
+	userService := users.New(cfg)
+	orderService := orders.New(cfg, proto.NewUserServiceClient(conn), proto.NewInventoryServiceClient(conn))
+
+	grpcServer := server.NewGRPCServer()
+	inventory.New().Register(grpcServer)
+	userService.Register(grpcServer)
+	orderService.Register(grpcServer)
+	// The services call each other in-process, so only the database is
+	// checked.
+	health := server.NewHealth(grpcServer)
+	health.AddCheck("postgres", server.PingDatabase)
+	go health.Run(ctx)
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- grpcServer.Serve(listener) }()
+
+	// Saga recovery calls the other services, so it starts once they serve.
+	userService.Start()
+	defer userService.Stop(cfg.ShutdownTimeout.Duration)
+	orderService.Start()
+	defer orderService.Stop(cfg.ShutdownTimeout.Duration)
+
+	gatewayErr := make(chan error, 1)
+	go func() { gatewayErr <- gateway.Run(ctx, cfg, grpc.NewClientsFromConn(conn)) }()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("gRPC services: %w", err)
+	case err = <-gatewayErr:
+	}
+
+	orderService.EndStreams()
+	server.GracefulStop(grpcServer, cfg.ShutdownTimeout.Duration)
+	return err
+}
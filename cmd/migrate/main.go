@@ -0,0 +1,120 @@
+package main
+
+import (
+	"FoodStore-AdvProg2/infrastructure/config"
+	"FoodStore-AdvProg2/infrastructure/postgres"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+)
+
+const usage = `usage: migrate [-service orders|users|inventory] <command>
+
+Without -service, up and status run for every service against its own
+database (ORDER_DB, USER_DB, INVENTORY_DB, or the config file). down and redo need -service.
+
+commands:
+  up        apply all pending migrations
+  down [n]  roll back the last n applied migrations (default 1)
+  status    list migrations and whether they are applied
+  redo      roll back the last applied migration and apply it again`
+
+func main() {
+	service := flag.String("service", "", "service whose database to migrate")
+	flag.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	command := args[0]
+
+	services := postgres.Services
+	if *service != "" {
+		services = []string{*service}
+	} else if command == "down" || command == "redo" {
+		log.Fatalf("%s needs -service", command)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	for _, svc := range services {
+		if err := run(cfg, svc, command, args[1:]); err != nil {
+			log.Fatalf("%s: %v", svc, err)
+		}
+	}
+}
+
+func run(cfg *config.Config, service, command string, args []string) error {
+	dbHost, err := cfg.DatabaseURL(service)
+	if err != nil {
+		return err
+	}
+
+	db, err := postgres.InitDB(dbHost)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	migrator, err := postgres.NewMigrator(db, service)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+		log.Printf("Applied %d %s migrations", applied, service)
+
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			steps, err = strconv.Atoi(args[0])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps: %s", args[0])
+			}
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return fmt.Errorf("rollback failed: %w", err)
+		}
+		log.Printf("Rolled back %d %s migrations", rolledBack, service)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read migration status: %w", err)
+		}
+		fmt.Printf("%s:\n", service)
+		for _, s := range statuses {
+			state := "pending"
+			if s.Applied {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("  %04d  %-30s  %s\n", s.Version, s.Name, state)
+		}
+
+	case "redo":
+		if err := migrator.Redo(ctx); err != nil {
+			return fmt.Errorf("redo failed: %w", err)
+		}
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCartEmpty         = errors.New("cart is empty")
+	ErrCartItemNotFound  = errors.New("product is not in the cart")
+	ErrCartChanged       = errors.New("cart has items whose price or availability changed; review the cart and check out again")
+	ErrInvalidQuantity   = errors.New("quantity must be positive")
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrProductNotFound   = errors.New("product not found")
+)
+
+// Problems a cart line can have when it is checked against inventory.
+const (
+	CartProblemPriceChanged      = "price_changed"
+	CartProblemInsufficientStock = "insufficient_stock"
+	CartProblemUnavailable       = "unavailable"
+)
+
+type Cart struct {
+	UserID     string     `json:"user_id"`
+	Items      []CartItem `json:"items"`
+	TotalPrice Money      `json:"total_price"`
+}
+
+// CartItem is one product in a cart. Price is the unit price the customer
+// saw when the line was last added or confirmed; CurrentPrice and Stock are
+// read from inventory each time the cart is loaded.
+type CartItem struct {
+	ProductID    string    `json:"product_id"`
+	Name         string    `json:"name"`
+	Quantity     int       `json:"quantity"`
+	Price        Money     `json:"price"`
+	CurrentPrice Money     `json:"current_price"`
+	Stock        int       `json:"stock"`
+	Problem      string    `json:"problem,omitempty"`
+	AddedAt      time.Time `json:"added_at"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "errors"
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryInUse    = errors.New("category still has subcategories")
+	ErrCategoryCycle    = errors.New("a category cannot be moved under itself or one of its subcategories")
+	ErrInvalidCategory  = errors.New("category name is required")
+	ErrCategoryExists   = errors.New("a category with this name already exists at this level")
+)
+
+// Category is a node in the menu tree. Top-level categories have an empty
+// ParentID. Filtering products by a category also matches products in its
+// subcategories.
+type Category struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id,omitempty"`
+}
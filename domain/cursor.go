@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// SortByCreatedAt is a sort key shared by every listing.
+const SortByCreatedAt = "created_at"
+
+type SortParams struct {
+	By   string
+	Desc bool
+}
+
+// Cursor marks a position in a sorted listing: the sort key value and ID of
+// the row it was taken from. A Before cursor reads the page ending just
+// before that row instead of the one starting after it.
+type Cursor struct {
+	Sort   SortParams
+	Value  string
+	ID     string
+	Before bool
+}
+
+type cursorJSON struct {
+	By     string `json:"s,omitempty"`
+	Desc   bool   `json:"d,omitempty"`
+	Value  string `json:"v,omitempty"`
+	ID     string `json:"id"`
+	Before bool   `json:"b,omitempty"`
+}
+
+// Encode returns the cursor as an opaque URL-safe token.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(cursorJSON{By: c.Sort.By, Desc: c.Sort.Desc, Value: c.Value, ID: c.ID, Before: c.Before})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a token made by Encode. The cursor must have been taken
+// from a listing sorted by sort.
+func DecodeCursor(token string, sort SortParams) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c cursorJSON
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if c.By != sort.By || c.Desc != sort.Desc {
+		return Cursor{}, fmt.Errorf("%w: it belongs to a listing with a different sort", ErrInvalidCursor)
+	}
+	return Cursor{Sort: sort, Value: c.Value, ID: c.ID, Before: c.Before}, nil
+}
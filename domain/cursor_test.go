@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{"id only", Cursor{ID: "42"}},
+		{"created_at ascending", Cursor{Sort: SortParams{By: SortByCreatedAt}, Value: "2024-05-01T10:00:00Z", ID: "a"}},
+		{"price descending", Cursor{Sort: SortParams{By: "price", Desc: true}, Value: "1250", ID: "b"}},
+		{"before", Cursor{Sort: SortParams{By: "name"}, Value: "Борщ", ID: "c", Before: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.cursor.Encode(), tt.cursor.Sort)
+			if err != nil {
+				t.Fatalf("DecodeCursor: %v", err)
+			}
+			if got != tt.cursor {
+				t.Errorf("DecodeCursor = %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	priceDesc := SortParams{By: "price", Desc: true}
+	tests := []struct {
+		name  string
+		token string
+		sort  SortParams
+	}{
+		{"not base64", "!!!", priceDesc},
+		{"not JSON", "bm90IGpzb24", priceDesc},
+		{"missing id", Cursor{Sort: priceDesc, Value: "1"}.Encode(), priceDesc},
+		{"other sort key", Cursor{Sort: SortParams{By: "name", Desc: true}, ID: "a"}.Encode(), priceDesc},
+		{"other direction", Cursor{Sort: SortParams{By: "price"}, ID: "a"}.Encode(), priceDesc},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.token, tt.sort); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor error = %v, want %v", err, ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+const (
+	EventOrderCreated       = "order.created"
+	EventOrderStatusChanged = "order.status_changed"
+)
+
+type OrderEvent struct {
+	ID        int64     `json:"id"`
+	OrderID   string    `json:"order_id"`
+	Type      string    `json:"type"`
+	Payload   []byte    `json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+	Attempts  int       `json:"attempts"`
+}
+
+// OrderEventPayload is the JSON body of an order event. TotalPrice repeats
+// Total in major units for consumers written before Total existed.
+type OrderEventPayload struct {
+	OrderID        string  `json:"order_id"`
+	UserID         string  `json:"user_id"`
+	Status         string  `json:"status"`
+	PreviousStatus string  `json:"previous_status,omitempty"`
+	Total          Money   `json:"total"`
+	TotalPrice     float64 `json:"total_price"`
+	ActorID        string  `json:"actor_id,omitempty"`
+	Reason         string  `json:"reason,omitempty"`
+}
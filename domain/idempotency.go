@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still in progress")
+	ErrIdempotencyKeyTooLong    = errors.New("idempotency key is too long")
+)
+
+// MaxIdempotencyKeyLength is the longest key the idempotency_keys table holds.
+const MaxIdempotencyKeyLength = 255
+
+type IdempotencyRecord struct {
+	UserID      string    `json:"user_id"`
+	Key         string    `json:"key"`
+	RequestHash string    `json:"request_hash"`
+	OrderID     string    `json:"order_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
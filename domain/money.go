@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultCurrency is used wherever an amount arrives without a currency,
+// such as the legacy floating-point price fields.
+const DefaultCurrency = "KZT"
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidCurrency  = errors.New("invalid currency")
+)
+
+// currencyExponents lists the number of minor-unit digits for currencies
+// that do not use two.
+var currencyExponents = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"OMR": 3,
+}
+
+// Money is an amount in the smallest unit of its currency (tiyn for KZT,
+// cents for USD), so sums and products never lose precision.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: NormalizeCurrency(currency)}
+}
+
+// NormalizeCurrency upper-cases an ISO 4217 code and fills in the default
+// currency when it is empty.
+func NormalizeCurrency(currency string) string {
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return strings.ToUpper(currency)
+}
+
+func ValidateCurrency(currency string) error {
+	if len(currency) != 3 {
+		return fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+		}
+	}
+	return nil
+}
+
+func currencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// MoneyFromFloat converts a major-unit amount such as 12.345 to Money. This
+// is the only place amounts are rounded: to the nearest minor unit, with
+// halves rounded away from zero.
+func MoneyFromFloat(value float64, currency string) Money {
+	currency = NormalizeCurrency(currency)
+	scale := math.Pow10(currencyExponent(currency))
+	return Money{Amount: int64(math.Round(value * scale)), Currency: currency}
+}
+
+// Float64 returns the amount in major units, for the legacy floating-point
+// fields.
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / math.Pow10(currencyExponent(NormalizeCurrency(m.Currency)))
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) Multiply(quantity int) Money {
+	return Money{Amount: m.Amount * int64(quantity), Currency: m.Currency}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency == "" {
+		return other, nil
+	}
+	if other.Currency != "" && other.Currency != m.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) String() string {
+	exp := currencyExponent(NormalizeCurrency(m.Currency))
+	return fmt.Sprintf("%.*f %s", exp, m.Float64(), NormalizeCurrency(m.Currency))
+}
+
+// UnmarshalJSON also accepts a bare number in major units, which is how
+// amounts were encoded before Money existed (for example in stored saga
+// payloads).
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var value float64
+	if err := json.Unmarshal(data, &value); err == nil {
+		*m = MoneyFromFloat(value, DefaultCurrency)
+		return nil
+	}
+
+	type money Money
+	var decoded money
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*m = Money(decoded)
+	return nil
+}
+
+// MoneyFromFields reads an amount from a message that carries it both in
+// minor units and in the legacy major-unit float. The minor-unit amount wins
+// when it is set.
+func MoneyFromFields(amount int64, currency string, legacy float64) Money {
+	if amount != 0 {
+		return NewMoney(amount, currency)
+	}
+	return MoneyFromFloat(legacy, currency)
+}
+
+// PriceBoundFromFields is MoneyFromFields for a price filter bound. A legacy
+// bound that rounds to zero minor units is rejected, since a zero bound
+// would silently not filter at all.
+func PriceBoundFromFields(amount int64, currency string, legacy float64) (Money, error) {
+	bound := MoneyFromFields(amount, currency, legacy)
+	if amount == 0 && legacy != 0 && bound.Amount == 0 {
+		return Money{}, fmt.Errorf("%w: %v is less than one minor unit of %s", ErrInvalidPriceFilter, legacy, bound.Currency)
+	}
+	return bound, nil
+}
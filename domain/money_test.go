@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		value    float64
+		currency string
+		want     Money
+	}{
+		{12.34, "KZT", Money{1234, "KZT"}},
+		{12.345, "usd", Money{1235, "USD"}},
+		{0.1 + 0.2, "USD", Money{30, "USD"}},
+		{-1.005, "USD", Money{-100, "USD"}},
+		{-2.5, "JPY", Money{-3, "JPY"}},
+		{1500.4, "JPY", Money{1500, "JPY"}},
+		{1.2345, "KWD", Money{1235, "KWD"}},
+		{9.99, "", Money{999, DefaultCurrency}},
+		{0.004, "USD", Money{0, "USD"}},
+	}
+	for _, tt := range tests {
+		if got := MoneyFromFloat(tt.value, tt.currency); got != tt.want {
+			t.Errorf("MoneyFromFloat(%v, %q) = %+v, want %+v", tt.value, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{Money{1234, "KZT"}, "12.34 KZT"},
+		{Money{500, "JPY"}, "500 JPY"},
+		{Money{1500, "BHD"}, "1.500 BHD"},
+		{Money{7, ""}, "0.07 KZT"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.money, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    Money
+		want    Money
+		wantErr error
+	}{
+		{"same currency", Money{100, "USD"}, Money{250, "USD"}, Money{350, "USD"}, nil},
+		{"zero value takes other", Money{}, Money{250, "EUR"}, Money{250, "EUR"}, nil},
+		{"other without currency", Money{100, "USD"}, Money{}, Money{100, "USD"}, nil},
+		{"mismatch", Money{100, "USD"}, Money{100, "EUR"}, Money{}, ErrCurrencyMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.Add(tt.b)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Add error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Add = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data string
+		want Money
+	}{
+		{`12.5`, Money{1250, DefaultCurrency}},
+		{`{"amount":1250,"currency":"USD"}`, Money{1250, "USD"}},
+	}
+	for _, tt := range tests {
+		var got Money
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", tt.data, err)
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestPriceBoundFromFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		currency string
+		legacy   float64
+		want     Money
+		wantErr  error
+	}{
+		{"minor units win", 500, "USD", 9.99, Money{500, "USD"}, nil},
+		{"legacy", 0, "USD", 9.99, Money{999, "USD"}, nil},
+		{"unset", 0, "USD", 0, Money{0, "USD"}, nil},
+		{"legacy rounds up to one minor unit", 0, "USD", 0.005, Money{1, "USD"}, nil},
+		{"legacy rounds to zero", 0, "USD", 0.004, Money{}, ErrInvalidPriceFilter},
+		{"legacy below one yen", 0, "JPY", 0.4, Money{}, ErrInvalidPriceFilter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PriceBoundFromFields(tt.amount, tt.currency, tt.legacy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PriceBoundFromFields error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("PriceBoundFromFields = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,36 +1,56 @@
 package domain
 
 import (
+	"errors"
 	"time"
 )
 
 const (
 	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusPreparing = "preparing"
+	OrderStatusReady     = "ready"
 	OrderStatusCompleted = "completed"
 	OrderStatusCancelled = "cancelled"
+	OrderStatusRefunded  = "refunded"
+	OrderStatusFailed    = "failed"
 )
 
+var ErrOrderNotFound = errors.New("order not found")
+
+// ErrMixedCurrencies is returned for an order whose products are priced in
+// different currencies.
+var ErrMixedCurrencies = errors.New("all products in an order must share one currency")
+
 type Order struct {
-	ID         string      `json:"id"`
-	UserID     string      `json:"user_id"`
-	TotalPrice float64     `json:"total_price"`
-	Status     string      `json:"status"`
-	CreatedAt  time.Time   `json:"created_at"`
-	Items      []OrderItem `json:"items,omitempty"`
+	ID              string      `json:"id"`
+	UserID          string      `json:"user_id"`
+	TotalPrice      Money       `json:"total_price"`
+	Status          string      `json:"status"`
+	CreatedAt       time.Time   `json:"created_at"`
+	StockReleasedAt *time.Time  `json:"stock_released_at,omitempty"`
+	Items           []OrderItem `json:"items,omitempty"`
+	// IdempotencyKey is the key the order was created under, if any. It is
+	// only set while the order is being created.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
+// OrderItem keeps the product's name and price as they were when the order
+// was placed, so it reads the same after the product changes or is deleted.
 type OrderItem struct {
-	ID        string   `json:"id"`
-	OrderID   string   `json:"order_id"`
-	ProductID string   `json:"product_id"`
-	Product   *Product `json:"product,omitempty"`
-	Quantity  int      `json:"quantity"`
-	Price     float64  `json:"price"`
+	ID          string   `json:"id"`
+	OrderID     string   `json:"order_id"`
+	ProductID   string   `json:"product_id"`
+	ProductName string   `json:"product_name"`
+	Product     *Product `json:"product,omitempty"`
+	Quantity    int      `json:"quantity"`
+	Price       Money    `json:"price"`
 }
 
 type OrderRequest struct {
-	UserID string             `json:"user_id"`
-	Items  []OrderItemRequest `json:"items"`
+	UserID         string             `json:"user_id"`
+	Items          []OrderItemRequest `json:"items"`
+	IdempotencyKey string             `json:"idempotency_key,omitempty"`
 }
 
 type OrderItemRequest struct {
@@ -40,4 +60,15 @@ type OrderItemRequest struct {
 
 type OrderStatusUpdateRequest struct {
 	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type OrderStatusChange struct {
+	ID        int64     `json:"id"`
+	OrderID   string    `json:"order_id"`
+	OldStatus string    `json:"old_status"`
+	NewStatus string    `json:"new_status"`
+	ActorID   string    `json:"actor_id,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
 }
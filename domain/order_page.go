@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Order listings can be sorted by SortByCreatedAt (the default, newest first)
+// and SortByTotal. Ties are ordered by ID.
+const SortByTotal = "total"
+
+var DefaultOrderSort = SortParams{By: SortByCreatedAt, Desc: true}
+
+func ValidateOrderSort(sort SortParams) error {
+	switch sort.By {
+	case SortByCreatedAt, SortByTotal:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidSort, sort.By)
+	}
+}
+
+// OrderFilter narrows an order listing. Empty fields match every order.
+// Orders are matched when CreatedFrom <= created_at < CreatedTo. A total bound
+// only matches orders in the bound's currency.
+type OrderFilter struct {
+	UserID      string
+	Statuses    []string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	MinTotal    Money
+	MaxTotal    Money
+}
+
+// Validate checks statuses and the currencies of total bounds; bounds are
+// normalized by the caller.
+func (f OrderFilter) Validate() error {
+	for _, status := range f.Statuses {
+		if !IsValidOrderStatus(status) {
+			return fmt.Errorf("%w: %q", ErrUnknownOrderStatus, status)
+		}
+	}
+	for _, bound := range []Money{f.MinTotal, f.MaxTotal} {
+		if bound.Amount > 0 {
+			if err := ValidateCurrency(bound.Currency); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NewOrderCursor takes a cursor from o for a listing sorted by sort.
+func NewOrderCursor(o Order, sort SortParams, before bool) Cursor {
+	var value string
+	switch sort.By {
+	case SortByCreatedAt:
+		value = o.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case SortByTotal:
+		value = strconv.FormatInt(o.TotalPrice.Amount, 10)
+	}
+	return Cursor{Sort: sort, Value: value, ID: o.ID, Before: before}
+}
+
+// OrderSortValue returns the cursor's value as the Go type of its order sort
+// key.
+func OrderSortValue(c Cursor) (interface{}, error) {
+	switch c.Sort.By {
+	case SortByCreatedAt:
+		return time.Parse(time.RFC3339Nano, c.Value)
+	case SortByTotal:
+		return strconv.ParseInt(c.Value, 10, 64)
+	default:
+		return nil, ErrInvalidCursor
+	}
+}
+
+// OrderPage is one page of an order listing. Page and Total are only set for
+// page-number requests. NextCursor and PrevCursor are empty at either end of
+// the listing.
+type OrderPage struct {
+	Orders     []Order `json:"orders"`
+	Page       int     `json:"page,omitempty"`
+	PerPage    int     `json:"per_page"`
+	Total      int     `json:"total,omitempty"`
+	NextCursor string  `json:"next_cursor"`
+	PrevCursor string  `json:"prev_cursor"`
+}
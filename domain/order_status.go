@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownOrderStatus = errors.New("unknown order status")
+	ErrOrderStatusChanged = errors.New("order status was changed concurrently")
+	ErrStatusNotSettable  = errors.New("order status cannot be set directly")
+)
+
+// InvalidTransitionError is returned when an order cannot move from its
+// current status to the requested one.
+type InvalidTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("cannot change order status from %s to %s", e.From, e.To)
+}
+
+// orderTransitions lists the statuses each status may move to. The happy path
+// is pending -> paid -> preparing -> ready -> completed; unpaid orders can be
+// cancelled and paid orders can be refunded. Cancelled, refunded and failed
+// orders are final.
+var orderTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusPaid, OrderStatusCancelled, OrderStatusFailed},
+	OrderStatusPaid:      {OrderStatusPreparing, OrderStatusRefunded},
+	OrderStatusPreparing: {OrderStatusReady, OrderStatusRefunded},
+	OrderStatusReady:     {OrderStatusCompleted, OrderStatusRefunded},
+	OrderStatusCompleted: {OrderStatusRefunded},
+	OrderStatusCancelled: {},
+	OrderStatusRefunded:  {},
+	OrderStatusFailed:    {},
+}
+
+// ReleasesStock reports whether moving an order into status returns its
+// reserved quantities to inventory.
+func ReleasesStock(status string) bool {
+	return status == OrderStatusCancelled || status == OrderStatusRefunded
+}
+
+func IsValidOrderStatus(status string) bool {
+	_, ok := orderTransitions[status]
+	return ok
+}
+
+// IsSettableOrderStatus reports whether staff may move an order into status.
+// Only a rolled-back order creation marks an order failed.
+func IsSettableOrderStatus(status string) bool {
+	return IsValidOrderStatus(status) && status != OrderStatusFailed
+}
+
+// NextOrderStatuses returns the statuses an order in the given status may move to.
+func NextOrderStatuses(status string) []string {
+	return append([]string(nil), orderTransitions[status]...)
+}
+
+func ValidateOrderTransition(from, to string) error {
+	if !IsValidOrderStatus(to) {
+		return fmt.Errorf("%w: %s", ErrUnknownOrderStatus, to)
+	}
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return &InvalidTransitionError{From: from, To: to}
+}
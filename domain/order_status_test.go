@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateOrderTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{OrderStatusPending, OrderStatusPaid, true},
+		{OrderStatusPending, OrderStatusCancelled, true},
+		{OrderStatusPending, OrderStatusFailed, true},
+		{OrderStatusPending, OrderStatusPreparing, false},
+		{OrderStatusPending, OrderStatusRefunded, false},
+		{OrderStatusPaid, OrderStatusPreparing, true},
+		{OrderStatusPaid, OrderStatusRefunded, true},
+		{OrderStatusPaid, OrderStatusCancelled, false},
+		{OrderStatusPreparing, OrderStatusReady, true},
+		{OrderStatusPreparing, OrderStatusPaid, false},
+		{OrderStatusReady, OrderStatusCompleted, true},
+		{OrderStatusReady, OrderStatusRefunded, true},
+		{OrderStatusCompleted, OrderStatusRefunded, true},
+		{OrderStatusCompleted, OrderStatusReady, false},
+		{OrderStatusCancelled, OrderStatusPaid, false},
+		{OrderStatusRefunded, OrderStatusPaid, false},
+		{OrderStatusFailed, OrderStatusPending, false},
+		{OrderStatusPaid, OrderStatusPaid, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			err := ValidateOrderTransition(tt.from, tt.to)
+			if tt.ok {
+				if err != nil {
+					t.Errorf("ValidateOrderTransition = %v, want nil", err)
+				}
+				return
+			}
+			var transitionErr *InvalidTransitionError
+			if !errors.As(err, &transitionErr) {
+				t.Fatalf("ValidateOrderTransition = %v, want *InvalidTransitionError", err)
+			}
+			if transitionErr.From != tt.from || transitionErr.To != tt.to {
+				t.Errorf("InvalidTransitionError = %+v, want %s -> %s", transitionErr, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestValidateOrderTransitionUnknownStatus(t *testing.T) {
+	if err := ValidateOrderTransition(OrderStatusPending, "shipped"); !errors.Is(err, ErrUnknownOrderStatus) {
+		t.Errorf("ValidateOrderTransition = %v, want %v", err, ErrUnknownOrderStatus)
+	}
+}
+
+func TestNextOrderStatusesMatchTransitions(t *testing.T) {
+	for from := range orderTransitions {
+		next := NextOrderStatuses(from)
+		for _, to := range next {
+			if err := ValidateOrderTransition(from, to); err != nil {
+				t.Errorf("NextOrderStatuses(%s) lists %s, but ValidateOrderTransition = %v", from, to, err)
+			}
+		}
+		if len(next) > 0 {
+			next[0] = "changed"
+			if NextOrderStatuses(from)[0] == "changed" {
+				t.Errorf("NextOrderStatuses(%s) shares its slice with the transition table", from)
+			}
+		}
+	}
+}
+
+func TestIsSettableOrderStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{OrderStatusPaid, true},
+		{OrderStatusCancelled, true},
+		{OrderStatusRefunded, true},
+		{OrderStatusFailed, false},
+		{"shipped", false},
+	}
+	for _, tt := range tests {
+		if got := IsSettableOrderStatus(tt.status); got != tt.want {
+			t.Errorf("IsSettableOrderStatus(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
@@ -1,20 +1,135 @@
 package domain
 
+import (
+    "errors"
+    "fmt"
+    "sort"
+    "strings"
+    "time"
+    "unicode/utf8"
+)
+
+var (
+    ErrProductArchived    = errors.New("product is archived")
+    ErrInvalidProduct     = errors.New("invalid product data")
+    ErrInvalidUnit        = errors.New("invalid unit")
+    ErrEmptySearch        = errors.New("search query is required")
+    ErrInvalidPriceFilter = errors.New("invalid price filter")
+    ErrInvalidLabel       = errors.New("invalid tag or allergen")
+    ErrInvalidStockChange = errors.New("invalid stock change")
+)
+
+// MaxLabelLength is the longest tag or allergen, in characters.
+const MaxLabelLength = 50
+
+// Units a product can be sold in. Stock is counted in the same unit.
+const (
+    UnitPiece    = "piece"
+    UnitKilogram = "kg"
+    UnitLitre    = "litre"
+)
+
+func IsValidUnit(unit string) bool {
+    switch unit {
+    case UnitPiece, UnitKilogram, UnitLitre:
+        return true
+    default:
+        return false
+    }
+}
+
+// Product is archived rather than deleted. An archived product keeps its row
+// so it can still be looked up, but it is not listed and cannot be ordered.
+//
+// Tags (vegan, gluten-free, spicy) and Allergens (milk, nuts) are lower-case
+// labels kept sorted and without duplicates.
 type Product struct {
-    ID         string
-    Name       string
-    Price      float64
-    Stock      int
+    ID          string
+    Name        string
+    Price       Money
+    Stock       int
+    CreatedAt   time.Time
+    ArchivedAt  *time.Time
+    Description string
+    ImageURL    string
+    Unit        string
+    CategoryID  string
+    Tags        []string
+    Allergens   []string
+}
+
+func (p Product) IsArchived() bool {
+    return p.ArchivedAt != nil
+}
+
+// NormalizeLabels trims and lower-cases tags or allergens, drops empty and
+// duplicate entries and sorts the rest.
+func NormalizeLabels(labels []string) []string {
+    seen := make(map[string]bool, len(labels))
+    normalized := make([]string, 0, len(labels))
+    for _, label := range labels {
+        label = strings.ToLower(strings.TrimSpace(label))
+        if label == "" || seen[label] {
+            continue
+        }
+        seen[label] = true
+        normalized = append(normalized, label)
+    }
+    sort.Strings(normalized)
+    return normalized
+}
+
+// ValidateLabels checks that every tag or allergen fits MaxLabelLength.
+func ValidateLabels(labels []string) error {
+    for _, label := range labels {
+        if utf8.RuneCountInString(label) > MaxLabelLength {
+            return fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidLabel, label, MaxLabelLength)
+        }
+    }
+    return nil
 }
 
 
+// FilterParams narrows a product listing. CategoryID also matches products in
+// its subcategories, a product must carry every tag in Tags, and it must
+// contain none of the allergens in ExcludeAllergens.
 type FilterParams struct {
-    Name     string
-    MinPrice float64
-    MaxPrice float64
+    Name             string
+    MinPrice         Money
+    MaxPrice         Money
+    IncludeArchived  bool
+    CategoryID       string
+    Tags             []string
+    ExcludeAllergens []string
+    Unit             string
+}
+
+// SearchResult is a product matched by a full-text search, most relevant
+// first. NameHighlight and Snippet are HTML: the name and an excerpt of the
+// description, escaped, with the matched words wrapped in <mark> tags.
+type SearchResult struct {
+    Product       Product
+    Rank          float64
+    NameHighlight string
+    Snippet       string
+}
+
+type TagCount struct {
+    Tag   string
+    Count int
 }
 
 type PaginationParams struct {
     Page    int
     PerPage int
+}
+
+type StockLine struct {
+    ProductID string
+    Quantity  int
+}
+
+type StockReservation struct {
+    Reference string
+    Lines     []StockLine
 }
\ No newline at end of file
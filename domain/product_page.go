@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Product listings can be sorted by these keys and SortByCreatedAt. Ties,
+// and listings without a sort key, are ordered by ID.
+const (
+	SortByPrice = "price"
+	SortByName  = "name"
+	SortByStock = "stock"
+)
+
+func ValidateProductSort(sort SortParams) error {
+	switch sort.By {
+	case "", SortByPrice, SortByName, SortByStock, SortByCreatedAt:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidSort, sort.By)
+	}
+}
+
+// NewProductCursor takes a cursor from p for a listing sorted by sort.
+func NewProductCursor(p Product, sort SortParams, before bool) Cursor {
+	var value string
+	switch sort.By {
+	case SortByPrice:
+		value = strconv.FormatInt(p.Price.Amount, 10)
+	case SortByName:
+		value = p.Name
+	case SortByStock:
+		value = strconv.Itoa(p.Stock)
+	case SortByCreatedAt:
+		value = p.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return Cursor{Sort: sort, Value: value, ID: p.ID, Before: before}
+}
+
+// ProductSortValue returns the cursor's value as the Go type of its product
+// sort key, or nil when the listing is sorted by ID only.
+func ProductSortValue(c Cursor) (interface{}, error) {
+	switch c.Sort.By {
+	case SortByPrice:
+		return strconv.ParseInt(c.Value, 10, 64)
+	case SortByStock:
+		return strconv.ParseInt(c.Value, 10, 32)
+	case SortByCreatedAt:
+		return time.Parse(time.RFC3339Nano, c.Value)
+	case SortByName:
+		return c.Value, nil
+	default:
+		return nil, nil
+	}
+}
+
+// ProductPage is one page of a product listing. Total is only counted for
+// page-number requests and is zero when the page was read by cursor.
+// NextCursor and PrevCursor are empty at either end of the listing.
+type ProductPage struct {
+	Products   []Product
+	Total      int
+	NextCursor string
+	PrevCursor string
+}
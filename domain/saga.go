@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+const (
+	SagaTypeCreateOrder = "create_order"
+
+	SagaStatusRunning      = "running"
+	SagaStatusCompensating = "compensating"
+	SagaStatusCompleted    = "completed"
+	SagaStatusCompensated  = "compensated"
+)
+
+type Saga struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	OrderID        string    `json:"order_id"`
+	Status         string    `json:"status"`
+	CompletedSteps []string  `json:"completed_steps"`
+	Payload        []byte    `json:"payload,omitempty"`
+	LastError      string    `json:"last_error,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
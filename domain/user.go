@@ -1,17 +1,64 @@
 package domain
 
-import "time"
+import (
+    "errors"
+    "time"
+)
+
+var (
+    ErrInvalidRole  = errors.New("invalid role")
+    ErrInvalidToken = errors.New("invalid token")
+    ErrTokenExpired = errors.New("token expired")
+    ErrUserNotFound = errors.New("user not found")
+)
+
+const (
+    RoleCustomer = "customer"
+    RoleStaff    = "staff"
+    RoleAdmin    = "admin"
+)
 
 type User struct {
     ID        string    `json:"id"`
     Username  string    `json:"username"`
     Email     string    `json:"email"`
     Password  string    `json:"password"`
+    Role      string    `json:"role"`
     CreatedAt time.Time `json:"created_at"`
 }
 
+func IsValidRole(role string) bool {
+    return role == RoleCustomer || role == RoleStaff || role == RoleAdmin
+}
+
 type Token struct {
     UserID    string    `json:"user_id"`
     Token     string    `json:"token"`
     CreatedAt time.Time `json:"created_at"`
-}
\ No newline at end of file
+    ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Session is what a login or refresh hands back: a short-lived signed access
+// token and the long-lived refresh token used to get the next one.
+type Session struct {
+    UserID           string    `json:"user_id"`
+    AccessToken      string    `json:"token"`
+    AccessExpiresAt  time.Time `json:"expires_at"`
+    RefreshToken     string    `json:"refresh_token"`
+    RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// AccessClaims is the content of a signed access token.
+type AccessClaims struct {
+    UserID    string
+    Role      string
+    IssuedAt  time.Time
+    ExpiresAt time.Time
+}
+
+// SigningKey is a public key that verifies access tokens.
+type SigningKey struct {
+    KeyID     string
+    Algorithm string
+    PublicKey []byte
+}
@@ -4,7 +4,10 @@ import (
 	"FoodStore-AdvProg2/domain"
 	"FoodStore-AdvProg2/usecase"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 )
@@ -27,12 +30,38 @@ func (h *OrderHandler) parseID(r *http.Request) string {
 	return mux.Vars(r)["id"]
 }
 
+// httpStatus maps domain errors to the HTTP status the gateway returns for
+// the same error after it crosses the order service.
+func httpStatus(err error) int {
+	var transitionErr *domain.InvalidTransitionError
+	switch {
+	case errors.Is(err, domain.ErrUnknownOrderStatus), errors.Is(err, domain.ErrStatusNotSettable), errors.Is(err, domain.ErrInvalidSort),
+		errors.Is(err, domain.ErrInvalidCursor), errors.Is(err, domain.ErrInvalidCurrency),
+		errors.Is(err, domain.ErrIdempotencyKeyTooLong), errors.Is(err, domain.ErrMixedCurrencies),
+		errors.Is(err, domain.ErrInvalidQuantity):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrOrderNotFound), errors.Is(err, domain.ErrProductNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.As(err, &transitionErr), errors.Is(err, domain.ErrOrderStatusChanged),
+		errors.Is(err, domain.ErrIdempotencyKeyInProgress), errors.Is(err, domain.ErrIdempotencyKeyReused),
+		errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrCurrencyMismatch),
+		errors.Is(err, domain.ErrProductArchived):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	var orderReq domain.OrderRequest
 	if err := json.NewDecoder(r.Body).Decode(&orderReq); err != nil {
 		http.Error(w, "Invalid request format", http.StatusBadRequest)
 		return
 	}
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		orderReq.IdempotencyKey = key
+	}
 
 	orderID, err := h.UC.CreateOrder(orderReq)
 	if err != nil {
@@ -55,38 +84,34 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 	h.respondJSON(w, order, http.StatusOK)
 }
 
+// UpdateOrderStatus is rejected: this handler does not authenticate callers,
+// and every status change must record who made it. Status changes go through
+// the API gateway, which passes the authenticated user as the actor.
 func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
-	id := h.parseID(r)
-
-	var statusReq domain.OrderStatusUpdateRequest
-	if err := json.NewDecoder(r.Body).Decode(&statusReq); err != nil {
-		http.Error(w, "Invalid request format", http.StatusBadRequest)
-		return
-	}
-
-	if err := h.UC.UpdateOrderStatus(id, statusReq.Status); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	h.respondJSON(w, map[string]string{"status": "updated"}, http.StatusOK)
+	http.Error(w, "order status changes require an authenticated user; use the API gateway", http.StatusForbidden)
 }
 
-func (h *OrderHandler) GetUserOrders(w http.ResponseWriter, r *http.Request) {
-	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		orders, err := h.UC.GetAllOrders()
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		h.respondJSON(w, orders, http.StatusOK)
+// ListOrders reads user_id, status (comma-separated), sort_by, sort_dir,
+// page, per_page, cursor and include_items from the query string. user_id is
+// required: this handler has no authentication to decide who may list every
+// user's orders.
+func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.OrderFilter{UserID: query.Get("user_id")}
+	if filter.UserID == "" {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
 		return
 	}
+	if status := query.Get("status"); status != "" {
+		filter.Statuses = strings.Split(status, ",")
+	}
+	sort := domain.SortParams{By: query.Get("sort_by"), Desc: query.Get("sort_dir") != "asc"}
+	page, _ := strconv.Atoi(query.Get("page"))
+	perPage, _ := strconv.Atoi(query.Get("per_page"))
 
-	orders, err := h.UC.GetOrdersByUserID(userID)
+	orders, err := h.UC.ListOrders(filter, sort, domain.PaginationParams{Page: page, PerPage: perPage}, query.Get("cursor"), query.Get("include_items") == "true")
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), httpStatus(err))
 		return
 	}
 
@@ -0,0 +1,225 @@
+package auth
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgorithmEdDSA = "EdDSA"
+	AlgorithmHS256 = "HS256"
+
+	issuer = "foodstore"
+)
+
+type accessClaims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Signer issues access tokens signed with an Ed25519 private key or an HMAC
+// secret, and verifies the tokens it issued.
+type Signer struct {
+	keyID     string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	public    ed25519.PublicKey
+}
+
+func NewEd25519Signer(privateKey ed25519.PrivateKey) *Signer {
+	public := privateKey.Public().(ed25519.PublicKey)
+	return &Signer{
+		keyID:     keyID(public),
+		method:    jwt.SigningMethodEdDSA,
+		signKey:   privateKey,
+		verifyKey: public,
+		public:    public,
+	}
+}
+
+func NewHMACSigner(secret []byte) (*Signer, error) {
+	if len(secret) < 32 {
+		return nil, errors.New("HMAC secret must be at least 32 bytes")
+	}
+	return &Signer{
+		keyID:     keyID(secret),
+		method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}, nil
+}
+
+// keyID derives a stable key ID from key material without revealing it.
+func keyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+func (s *Signer) Sign(claims domain.AccessClaims) (string, error) {
+	token := jwt.NewWithClaims(s.method, accessClaims{
+		Role: claims.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer,
+			Subject:   claims.UserID,
+			IssuedAt:  jwt.NewNumericDate(claims.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
+	})
+	token.Header["kid"] = s.keyID
+	return token.SignedString(s.signKey)
+}
+
+func (s *Signer) Verify(token string) (domain.AccessClaims, error) {
+	return parse(token, s.method.Alg(), func(kid string) (interface{}, bool) {
+		return s.verifyKey, kid == s.keyID
+	})
+}
+
+// PublicKeys lists the keys that verify this signer's tokens. HMAC secrets are
+// never published, so an HMAC signer has none.
+func (s *Signer) PublicKeys() []domain.SigningKey {
+	if s.public == nil {
+		return nil
+	}
+	return []domain.SigningKey{{
+		KeyID:     s.keyID,
+		Algorithm: AlgorithmEdDSA,
+		PublicKey: s.public,
+	}}
+}
+
+// parse checks the signature, issuer and expiry of an access token. lookup
+// returns the verification key for a key ID.
+func parse(token, algorithm string, lookup func(kid string) (interface{}, bool)) (domain.AccessClaims, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := lookup(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return key, nil
+	},
+		jwt.WithValidMethods([]string{algorithm}),
+		jwt.WithIssuer(issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return domain.AccessClaims{}, domain.ErrTokenExpired
+	}
+	if err != nil || claims.Subject == "" {
+		return domain.AccessClaims{}, domain.ErrInvalidToken
+	}
+
+	return domain.AccessClaims{
+		UserID:    claims.Subject,
+		Role:      claims.Role,
+		IssuedAt:  claims.IssuedAt.Time,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// Verifier checks access tokens away from the service that signed them. For
+// Ed25519 it uses the published public keys, fetching them again when a token
+// names a key it has not seen (for example after the signer restarted with a
+// new key). For HMAC it needs the shared secret.
+type Verifier struct {
+	algorithm string
+	secret    []byte
+	fetch     func() ([]domain.SigningKey, error)
+
+	mu          sync.RWMutex
+	keys        map[string]ed25519.PublicKey
+	keysFetched time.Time // when the fetch that produced keys started
+	lastFetched time.Time
+}
+
+// minRefetchInterval stops tokens with made-up key IDs from turning every
+// request into a key fetch.
+const minRefetchInterval = 30 * time.Second
+
+func NewEd25519Verifier(fetch func() ([]domain.SigningKey, error)) *Verifier {
+	return &Verifier{
+		algorithm: AlgorithmEdDSA,
+		fetch:     fetch,
+		keys:      make(map[string]ed25519.PublicKey),
+	}
+}
+
+func NewHMACVerifier(secret []byte) *Verifier {
+	return &Verifier{algorithm: AlgorithmHS256, secret: secret}
+}
+
+func (v *Verifier) Verify(token string) (domain.AccessClaims, error) {
+	if v.algorithm == AlgorithmHS256 {
+		kid := keyID(v.secret)
+		return parse(token, AlgorithmHS256, func(k string) (interface{}, bool) {
+			return v.secret, k == kid
+		})
+	}
+	return parse(token, AlgorithmEdDSA, v.publicKey)
+}
+
+func (v *Verifier) publicKey(kid string) (interface{}, bool) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	v.mu.RUnlock()
+	if ok {
+		return key, true
+	}
+
+	if err := v.Refresh(false); err != nil {
+		return nil, false
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	key, ok = v.keys[kid]
+	return key, ok
+}
+
+// Refresh reloads the public keys. Unless force is set, it does nothing if
+// the keys were fetched recently. The fetch runs without holding the lock, so
+// requests with known keys are verified while it is in flight.
+func (v *Verifier) Refresh(force bool) error {
+	v.mu.Lock()
+	if !force && time.Since(v.lastFetched) < minRefetchInterval {
+		v.mu.Unlock()
+		return nil
+	}
+	started := time.Now()
+	v.lastFetched = started
+	v.mu.Unlock()
+
+	fetched, err := v.fetch()
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]ed25519.PublicKey, len(fetched))
+	for _, key := range fetched {
+		if key.Algorithm != AlgorithmEdDSA || len(key.PublicKey) != ed25519.PublicKeySize {
+			continue
+		}
+		keys[key.KeyID] = ed25519.PublicKey(key.PublicKey)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	// A forced fetch that started later may have finished first; its keys
+	// are newer.
+	if started.Before(v.keysFetched) {
+		return nil
+	}
+	v.keys, v.keysFetched = keys, started
+	return nil
+}
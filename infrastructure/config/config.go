@@ -0,0 +1,352 @@
+// Package config loads the settings shared by every FoodStore binary.
+//
+// Settings are layered, each layer overriding the one before it: built-in
+// defaults, the optional YAML or TOML file named by CONFIG_FILE, and the
+// environment. A .env file (or the file named by ENV_FILE) is loaded into the
+// environment first without replacing variables that are already set, so
+// separate environments can run side by side from different env or config
+// files.
+package config
+
+import (
+	"FoodStore-AdvProg2/infrastructure/auth"
+	"FoodStore-AdvProg2/infrastructure/postgres"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Gateway   GatewayConfig   `yaml:"gateway" toml:"gateway"`
+	Orders    OrderConfig     `yaml:"orders" toml:"orders"`
+	Users     UserConfig      `yaml:"users" toml:"users"`
+	Inventory InventoryConfig `yaml:"inventory" toml:"inventory"`
+	// Database is the shared connection string used by services whose own
+	// database is not set.
+	Database string    `yaml:"database" toml:"database"`
+	JWT      JWTConfig `yaml:"jwt" toml:"jwt"`
+	// ShutdownTimeout bounds each stage of a graceful shutdown: draining
+	// requests, then stopping background workers.
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+}
+
+// GatewayConfig sets the gateway's ports. Metrics are served apart from the
+// public API on MetricsPort, or not at all when it is 0. On shutdown /readyz
+// fails for DrainDelay before the gateway stops accepting connections, so
+// load balancers stop routing new requests to it first.
+type GatewayConfig struct {
+	Port        int      `yaml:"port" toml:"port"`
+	MetricsPort int      `yaml:"metrics_port" toml:"metrics_port"`
+	DrainDelay  Duration `yaml:"drain_delay" toml:"drain_delay"`
+}
+
+// A service listens on Port and is dialed by other services at URL, which
+// defaults to localhost on Port. It serves Prometheus metrics over HTTP on
+// MetricsPort, or not at all when MetricsPort is 0.
+type OrderConfig struct {
+	Port           int      `yaml:"port" toml:"port"`
+	URL            string   `yaml:"url" toml:"url"`
+	MetricsPort    int      `yaml:"metrics_port" toml:"metrics_port"`
+	Database       string   `yaml:"database" toml:"database"`
+	OutboxInterval Duration `yaml:"outbox_interval" toml:"outbox_interval"`
+}
+
+type UserConfig struct {
+	Port               int      `yaml:"port" toml:"port"`
+	URL                string   `yaml:"url" toml:"url"`
+	MetricsPort        int      `yaml:"metrics_port" toml:"metrics_port"`
+	Database           string   `yaml:"database" toml:"database"`
+	AccessTokenTTL     Duration `yaml:"access_token_ttl" toml:"access_token_ttl"`
+	RefreshTokenTTL    Duration `yaml:"refresh_token_ttl" toml:"refresh_token_ttl"`
+	TokenSweepInterval Duration `yaml:"token_sweep_interval" toml:"token_sweep_interval"`
+}
+
+type InventoryConfig struct {
+	Port        int    `yaml:"port" toml:"port"`
+	URL         string `yaml:"url" toml:"url"`
+	MetricsPort int    `yaml:"metrics_port" toml:"metrics_port"`
+	Database    string `yaml:"database" toml:"database"`
+}
+
+// JWTConfig selects how access tokens are signed. PrivateKey is a base64
+// Ed25519 seed for EdDSA; Secret is the shared HS256 key.
+type JWTConfig struct {
+	SigningAlg string `yaml:"signing_alg" toml:"signing_alg"`
+	PrivateKey string `yaml:"private_key" toml:"private_key"`
+	Secret     string `yaml:"secret" toml:"secret"`
+}
+
+// Duration is a time.Duration written as a string such as "15m" or "720h".
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+func Default() *Config {
+	return &Config{
+		Gateway: GatewayConfig{Port: 8080, MetricsPort: 9090, DrainDelay: Duration{5 * time.Second}},
+		Orders: OrderConfig{
+			Port:           50051,
+			MetricsPort:    9091,
+			OutboxInterval: Duration{time.Second},
+		},
+		Users: UserConfig{
+			Port:               50052,
+			MetricsPort:        9092,
+			AccessTokenTTL:     Duration{15 * time.Minute},
+			RefreshTokenTTL:    Duration{30 * 24 * time.Hour},
+			TokenSweepInterval: Duration{10 * time.Minute},
+		},
+		Inventory:       InventoryConfig{Port: 50053, MetricsPort: 9093},
+		JWT:             JWTConfig{SigningAlg: auth.AlgorithmEdDSA},
+		ShutdownTimeout: Duration{15 * time.Second},
+	}
+}
+
+// Load reads the configuration and validates it.
+func Load() (*Config, error) {
+	if err := loadEnvFile(); err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Orders.URL == "" {
+		cfg.Orders.URL = localAddr(cfg.Orders.Port)
+	}
+	if cfg.Users.URL == "" {
+		cfg.Users.URL = localAddr(cfg.Users.Port)
+	}
+	if cfg.Inventory.URL == "" {
+		cfg.Inventory.URL = localAddr(cfg.Inventory.Port)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadEnvFile loads ENV_FILE, which must exist, or .env if there is one.
+func loadEnvFile() error {
+	if path := os.Getenv("ENV_FILE"); path != "" {
+		if err := godotenv.Load(path); err != nil {
+			return fmt.Errorf("env file %s: %w", path, err)
+		}
+		return nil
+	}
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("env file .env: %w", err)
+	}
+	return nil
+}
+
+// loadFile decodes a YAML or TOML file, chosen by extension, over cfg.
+// Unknown keys are rejected so that typos do not silently fall back to
+// defaults.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	case ".toml":
+		dec := toml.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(c); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported format %q: use .yaml, .yml or .toml", filepath.Ext(path))
+	}
+	return nil
+}
+
+// loadEnv overrides settings with the environment variables that are set.
+func (c *Config) loadEnv() error {
+	var errs []error
+	str := func(key string, dst *string) {
+		if value := os.Getenv(key); value != "" {
+			*dst = value
+		}
+	}
+	port := func(key string, dst *int) {
+		if value := os.Getenv(key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s %q: must be a port number", key, value))
+				return
+			}
+			*dst = n
+		}
+	}
+	duration := func(key string, dst *Duration) {
+		if value := os.Getenv(key); value != "" {
+			if err := dst.UnmarshalText([]byte(value)); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s %q: must be a duration such as 10m", key, value))
+			}
+		}
+	}
+
+	port("API_GATEWAY_PORT", &c.Gateway.Port)
+	port("API_GATEWAY_METRICS_PORT", &c.Gateway.MetricsPort)
+	duration("GATEWAY_DRAIN_DELAY", &c.Gateway.DrainDelay)
+
+	port("ORDER_SERVICE_PORT", &c.Orders.Port)
+	str("ORDER_SERVICE_GRPC_URL", &c.Orders.URL)
+	port("ORDER_SERVICE_METRICS_PORT", &c.Orders.MetricsPort)
+	str("ORDER_DB", &c.Orders.Database)
+	duration("OUTBOX_INTERVAL", &c.Orders.OutboxInterval)
+
+	port("USER_SERVICE_PORT", &c.Users.Port)
+	str("USER_SERVICE_GRPC_URL", &c.Users.URL)
+	port("USER_SERVICE_METRICS_PORT", &c.Users.MetricsPort)
+	str("USER_DB", &c.Users.Database)
+	duration("ACCESS_TOKEN_TTL", &c.Users.AccessTokenTTL)
+	duration("REFRESH_TOKEN_TTL", &c.Users.RefreshTokenTTL)
+	duration("TOKEN_SWEEP_INTERVAL", &c.Users.TokenSweepInterval)
+
+	port("INVENTORY_SERVICE_PORT", &c.Inventory.Port)
+	str("INVENTORY_SERVICE_GRPC_URL", &c.Inventory.URL)
+	port("INVENTORY_SERVICE_METRICS_PORT", &c.Inventory.MetricsPort)
+	str("INVENTORY_DB", &c.Inventory.Database)
+
+	str("DB", &c.Database)
+
+	str("JWT_SIGNING_ALG", &c.JWT.SigningAlg)
+	str("JWT_PRIVATE_KEY", &c.JWT.PrivateKey)
+	str("JWT_SECRET", &c.JWT.Secret)
+
+	duration("SHUTDOWN_TIMEOUT", &c.ShutdownTimeout)
+
+	return errors.Join(errs...)
+}
+
+// Validate reports every invalid setting at once. Database URLs are checked
+// by DatabaseURL, since only the services that use a database need one.
+func (c *Config) Validate() error {
+	var errs []error
+	for _, p := range []struct {
+		name string
+		port int
+	}{
+		{"gateway", c.Gateway.Port},
+		{"orders", c.Orders.Port},
+		{"users", c.Users.Port},
+		{"inventory", c.Inventory.Port},
+	} {
+		if p.port < 1 || p.port > 65535 {
+			errs = append(errs, fmt.Errorf("%s port %d is out of range", p.name, p.port))
+		}
+	}
+	for _, p := range []struct {
+		name string
+		port int
+	}{
+		{"gateway", c.Gateway.MetricsPort},
+		{"orders", c.Orders.MetricsPort},
+		{"users", c.Users.MetricsPort},
+		{"inventory", c.Inventory.MetricsPort},
+	} {
+		if p.port < 0 || p.port > 65535 {
+			errs = append(errs, fmt.Errorf("%s metrics_port %d is out of range", p.name, p.port))
+		}
+	}
+
+	for _, d := range []struct {
+		name  string
+		value Duration
+	}{
+		{"orders outbox_interval", c.Orders.OutboxInterval},
+		{"users access_token_ttl", c.Users.AccessTokenTTL},
+		{"users refresh_token_ttl", c.Users.RefreshTokenTTL},
+		{"users token_sweep_interval", c.Users.TokenSweepInterval},
+		{"shutdown_timeout", c.ShutdownTimeout},
+	} {
+		if d.value.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive duration", d.name))
+		}
+	}
+	if c.Gateway.DrainDelay.Duration < 0 {
+		errs = append(errs, errors.New("gateway drain_delay must not be negative"))
+	}
+
+	switch c.JWT.SigningAlg {
+	case auth.AlgorithmEdDSA:
+	case auth.AlgorithmHS256:
+		if c.JWT.Secret == "" {
+			errs = append(errs, errors.New("jwt secret must be set when signing_alg is HS256"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unsupported jwt signing_alg %q", c.JWT.SigningAlg))
+	}
+
+	return errors.Join(errs...)
+}
+
+// DatabaseURL returns the connection string for a service's own database.
+// It falls back to Database, which all services shared before they were split.
+func (c *Config) DatabaseURL(service string) (string, error) {
+	var url, key string
+	switch service {
+	case postgres.ServiceOrders:
+		url, key = c.Orders.Database, "ORDER_DB"
+	case postgres.ServiceUsers:
+		url, key = c.Users.Database, "USER_DB"
+	case postgres.ServiceInventory:
+		url, key = c.Inventory.Database, "INVENTORY_DB"
+	default:
+		return "", fmt.Errorf("%w: %q", postgres.ErrUnknownService, service)
+	}
+	if url != "" {
+		return url, nil
+	}
+	if c.Database != "" {
+		log.Printf("Warning: %s not set, using the shared DB database", key)
+		return c.Database, nil
+	}
+	return "", fmt.Errorf("%s database is not configured: set %s or %s.database", service, key, service)
+}
+
+// ListenAddr returns the address a server listens on for port.
+func ListenAddr(port int) string {
+	return ":" + strconv.Itoa(port)
+}
+
+func localAddr(port int) string {
+	return "localhost:" + strconv.Itoa(port)
+}
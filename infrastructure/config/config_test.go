@@ -0,0 +1,191 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv unsets the variables Load reads for the duration of the test.
+// They are unset rather than emptied, since an env file does not replace a
+// variable that is set even to "".
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		"ENV_FILE", "CONFIG_FILE", "API_GATEWAY_PORT", "API_GATEWAY_METRICS_PORT", "GATEWAY_DRAIN_DELAY",
+		"ORDER_SERVICE_PORT", "ORDER_SERVICE_GRPC_URL", "ORDER_DB", "OUTBOX_INTERVAL",
+		"USER_SERVICE_PORT", "ACCESS_TOKEN_TTL", "INVENTORY_SERVICE_PORT",
+		"DB", "JWT_SIGNING_ALG", "JWT_SECRET", "SHUTDOWN_TIMEOUT",
+	} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	yamlFile := `
+gateway:
+  port: 8081
+orders:
+  port: 6000
+  outbox_interval: 5s
+database: postgres://file/shared
+`
+	tomlFile := `
+database = "postgres://file/shared"
+
+[gateway]
+port = 8081
+
+[orders]
+port = 6000
+outbox_interval = "5s"
+`
+	tests := []struct {
+		name   string
+		file   string
+		body   string
+		env    map[string]string
+		assert func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "defaults",
+			assert: func(t *testing.T, cfg *Config) {
+				if cfg.Gateway.Port != 8080 || cfg.Orders.Port != 50051 || cfg.Orders.URL != "localhost:50051" {
+					t.Errorf("got gateway port %d, orders port %d and URL %q", cfg.Gateway.Port, cfg.Orders.Port, cfg.Orders.URL)
+				}
+			},
+		},
+		{
+			name: "yaml over defaults",
+			file: "config.yaml",
+			body: yamlFile,
+			assert: func(t *testing.T, cfg *Config) {
+				if cfg.Gateway.Port != 8081 || cfg.Orders.Port != 6000 || cfg.Orders.OutboxInterval.Duration != 5*time.Second {
+					t.Errorf("got gateway port %d, orders port %d, outbox interval %s", cfg.Gateway.Port, cfg.Orders.Port, cfg.Orders.OutboxInterval)
+				}
+				if cfg.Users.Port != 50052 {
+					t.Errorf("users port = %d, want the default 50052", cfg.Users.Port)
+				}
+				if cfg.Orders.URL != "localhost:6000" {
+					t.Errorf("orders URL = %q, want it derived from the file's port", cfg.Orders.URL)
+				}
+			},
+		},
+		{
+			name: "toml over defaults",
+			file: "config.toml",
+			body: tomlFile,
+			assert: func(t *testing.T, cfg *Config) {
+				if cfg.Gateway.Port != 8081 || cfg.Orders.Port != 6000 || cfg.Database != "postgres://file/shared" {
+					t.Errorf("got gateway port %d, orders port %d, database %q", cfg.Gateway.Port, cfg.Orders.Port, cfg.Database)
+				}
+			},
+		},
+		{
+			name: "env over yaml",
+			file: "config.yml",
+			body: yamlFile,
+			env:  map[string]string{"API_GATEWAY_PORT": "9000", "OUTBOX_INTERVAL": "250ms", "DB": "postgres://env/shared"},
+			assert: func(t *testing.T, cfg *Config) {
+				if cfg.Gateway.Port != 9000 || cfg.Orders.OutboxInterval.Duration != 250*time.Millisecond || cfg.Database != "postgres://env/shared" {
+					t.Errorf("got gateway port %d, outbox interval %s, database %q", cfg.Gateway.Port, cfg.Orders.OutboxInterval, cfg.Database)
+				}
+				if cfg.Orders.Port != 6000 {
+					t.Errorf("orders port = %d, want 6000 from the file", cfg.Orders.Port)
+				}
+			},
+		},
+		{
+			name: "env over toml",
+			file: "config.toml",
+			body: tomlFile,
+			env:  map[string]string{"ORDER_SERVICE_PORT": "7000", "ORDER_SERVICE_GRPC_URL": "orders:7000"},
+			assert: func(t *testing.T, cfg *Config) {
+				if cfg.Orders.Port != 7000 || cfg.Orders.URL != "orders:7000" || cfg.Gateway.Port != 8081 {
+					t.Errorf("got orders port %d and URL %q, gateway port %d", cfg.Orders.Port, cfg.Orders.URL, cfg.Gateway.Port)
+				}
+			},
+		},
+		{
+			name: "env file does not replace the environment",
+			file: ".env",
+			body: "API_GATEWAY_PORT=9100\nUSER_SERVICE_PORT=7100\n",
+			env:  map[string]string{"API_GATEWAY_PORT": "9000"},
+			assert: func(t *testing.T, cfg *Config) {
+				if cfg.Gateway.Port != 9000 || cfg.Users.Port != 7100 {
+					t.Errorf("got gateway port %d, users port %d", cfg.Gateway.Port, cfg.Users.Port)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			if tt.file != "" {
+				path := writeFile(t, tt.file, tt.body)
+				if tt.file == ".env" {
+					t.Setenv("ENV_FILE", path)
+				} else {
+					t.Setenv("CONFIG_FILE", path)
+				}
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			tt.assert(t, cfg)
+		})
+	}
+}
+
+func TestLoadRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		body    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"unknown yaml key", "config.yaml", "gateway:\n  prot: 8081\n", nil, "prot"},
+		{"unknown toml key", "config.toml", "[gateway]\nprot = 8081\n", nil, "strict mode"},
+		{"unsupported format", "config.json", "{}", nil, "unsupported format"},
+		{"bad port", "", "", map[string]string{"API_GATEWAY_PORT": "http"}, "API_GATEWAY_PORT"},
+		{"bad duration", "", "", map[string]string{"SHUTDOWN_TIMEOUT": "soon"}, "SHUTDOWN_TIMEOUT"},
+		{"port out of range", "config.yaml", "gateway:\n  port: 70000\n", nil, "gateway port 70000"},
+		{"metrics port out of range", "", "", map[string]string{"API_GATEWAY_METRICS_PORT": "-1"}, "gateway metrics_port -1"},
+		{"negative drain delay", "", "", map[string]string{"GATEWAY_DRAIN_DELAY": "-1s"}, "drain_delay"},
+		{"HS256 without secret", "", "", map[string]string{"JWT_SIGNING_ALG": "HS256"}, "jwt secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			if tt.file != "" {
+				t.Setenv("CONFIG_FILE", writeFile(t, tt.file, tt.body))
+			}
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,32 +1,47 @@
 package grpc
 
 import (
+	"FoodStore-AdvProg2/infrastructure/metrics"
 	"FoodStore-AdvProg2/proto"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type Clients struct {
 	InventoryClient proto.InventoryServiceClient
 	OrderClient     proto.OrderServiceClient
+	CartClient      proto.CartServiceClient
 	UserClient      proto.UserServiceClient
-	conns           []*grpc.ClientConn
+	// Health holds each service's grpc.health.v1 client by service name:
+	// "inventory", "orders" and "users".
+	Health map[string]healthpb.HealthClient
+	conns  []*grpc.ClientConn
+}
+
+// DialOptions are the options every connection to a service is dialed with.
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(metrics.UnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(metrics.StreamClientInterceptor),
+	}
 }
 
 func NewClients(inventoryAddr, orderAddr, userAddr string) (*Clients, error) {
-	inventoryConn, err := grpc.Dial(inventoryAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	inventoryConn, err := grpc.Dial(inventoryAddr, DialOptions()...)
 	if err != nil {
 		return nil, err
 	}
 
-	orderConn, err := grpc.Dial(orderAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	orderConn, err := grpc.Dial(orderAddr, DialOptions()...)
 	if err != nil {
 		inventoryConn.Close()
 		return nil, err
 	}
 
-	userConn, err := grpc.Dial(userAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	userConn, err := grpc.Dial(userAddr, DialOptions()...)
 	if err != nil {
 		inventoryConn.Close()
 		orderConn.Close()
@@ -36,13 +51,35 @@ func NewClients(inventoryAddr, orderAddr, userAddr string) (*Clients, error) {
 	clients := &Clients{
 		InventoryClient: proto.NewInventoryServiceClient(inventoryConn),
 		OrderClient:     proto.NewOrderServiceClient(orderConn),
+		CartClient:      proto.NewCartServiceClient(orderConn),
 		UserClient:      proto.NewUserServiceClient(userConn),
-		conns:           []*grpc.ClientConn{inventoryConn, orderConn, userConn},
+		Health: map[string]healthpb.HealthClient{
+			"inventory": healthpb.NewHealthClient(inventoryConn),
+			"orders":    healthpb.NewHealthClient(orderConn),
+			"users":     healthpb.NewHealthClient(userConn),
+		},
+		conns: []*grpc.ClientConn{inventoryConn, orderConn, userConn},
 	}
 
 	return clients, nil
 }
 
+// NewClientsFromConn calls every service over conn, for services served
+// together in one process. The caller closes conn.
+func NewClientsFromConn(conn grpc.ClientConnInterface) *Clients {
+	return &Clients{
+		InventoryClient: proto.NewInventoryServiceClient(conn),
+		OrderClient:     proto.NewOrderServiceClient(conn),
+		CartClient:      proto.NewCartServiceClient(conn),
+		UserClient:      proto.NewUserServiceClient(conn),
+		Health: map[string]healthpb.HealthClient{
+			"inventory": healthpb.NewHealthClient(conn),
+			"orders":    healthpb.NewHealthClient(conn),
+			"users":     healthpb.NewHealthClient(conn),
+		},
+	}
+}
+
 func (c *Clients) Close() {
 	for _, conn := range c.conns {
 		conn.Close()
@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/proto"
+	"errors"
+	"sync"
+)
+
+// OrderEventBroadcaster fans outbox events out to SubscribeOrderEvents
+// streams. Publish fails while any subscriber's buffer is full so the outbox
+// relay retries the event instead of dropping it.
+type OrderEventBroadcaster struct {
+	mu          sync.Mutex
+	subscribers map[*orderEventSubscriber]struct{}
+	buffer      int
+	closed      bool
+}
+
+type orderEventSubscriber struct {
+	events chan *proto.OrderEvent
+	types  map[string]bool
+}
+
+func NewOrderEventBroadcaster(buffer int) *OrderEventBroadcaster {
+	return &OrderEventBroadcaster{
+		subscribers: make(map[*orderEventSubscriber]struct{}),
+		buffer:      buffer,
+	}
+}
+
+func (b *OrderEventBroadcaster) Subscribe(eventTypes []string) (<-chan *proto.OrderEvent, func()) {
+	sub := &orderEventSubscriber{
+		events: make(chan *proto.OrderEvent, b.buffer),
+		types:  make(map[string]bool, len(eventTypes)),
+	}
+	for _, t := range eventTypes {
+		sub.types[t] = true
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		close(sub.events)
+	} else {
+		b.subscribers[sub] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	return sub.events, func() {
+		b.mu.Lock()
+		delete(b.subscribers, sub)
+		b.mu.Unlock()
+	}
+}
+
+// Close closes every subscriber's channel, and those of later subscribers, so
+// that streams end when the server shuts down.
+func (b *OrderEventBroadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subscribers {
+		close(sub.events)
+		delete(b.subscribers, sub)
+	}
+}
+
+func (b *OrderEventBroadcaster) Publish(event domain.OrderEvent) error {
+	msg := &proto.OrderEvent{
+		Id:        event.ID,
+		OrderId:   event.OrderID,
+		EventType: event.Type,
+		Payload:   string(event.Payload),
+		CreatedAt: event.CreatedAt.Unix(),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	full := 0
+	for sub := range b.subscribers {
+		if len(sub.types) > 0 && !sub.types[event.Type] {
+			continue
+		}
+		select {
+		case sub.events <- msg:
+		default:
+			full++
+		}
+	}
+	if full > 0 {
+		return errors.New("order event subscriber buffer is full")
+	}
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type ProductClient struct {
@@ -13,7 +12,7 @@ type ProductClient struct {
 }
 
 func NewProductClient(addr string) (*ProductClient, *grpc.ClientConn) {
-	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(addr, DialOptions()...)
 	if err != nil {
 		panic(err)
 	}
@@ -29,10 +28,18 @@ func (c *ProductClient) DeleteProduct(ctx context.Context, in *proto.DeleteProdu
 	return c.client.DeleteProduct(ctx, in, opts...)
 }
 
+func (c *ProductClient) RestoreProduct(ctx context.Context, in *proto.RestoreProductRequest, opts ...grpc.CallOption) (*proto.RestoreProductResponse, error) {
+	return c.client.RestoreProduct(ctx, in, opts...)
+}
+
 func (c *ProductClient) ListProducts(ctx context.Context, in *proto.ListProductsRequest, opts ...grpc.CallOption) (*proto.ListProductsResponse, error) {
 	return c.client.ListProducts(ctx, in, opts...)
 }
 
+func (c *ProductClient) SearchProducts(ctx context.Context, in *proto.SearchProductsRequest, opts ...grpc.CallOption) (*proto.SearchProductsResponse, error) {
+	return c.client.SearchProducts(ctx, in, opts...)
+}
+
 func (c *ProductClient) UpdateProduct(ctx context.Context, in *proto.UpdateProductRequest, opts ...grpc.CallOption) (*proto.UpdateProductResponse, error) {
 	return c.client.UpdateProduct(ctx, in, opts...)
 }
@@ -41,6 +48,42 @@ func (c *ProductClient) GetProduct(ctx context.Context, in *proto.GetProductRequ
 	return c.client.GetProduct(ctx, in, opts...)
 }
 
+func (c *ProductClient) GetProducts(ctx context.Context, in *proto.GetProductsRequest, opts ...grpc.CallOption) (*proto.GetProductsResponse, error) {
+	return c.client.GetProducts(ctx, in, opts...)
+}
+
 func (c *ProductClient) UpdateStock(ctx context.Context, in *proto.UpdateStockRequest, opts ...grpc.CallOption) (*proto.UpdateStockResponse, error) {
 	return c.client.UpdateStock(ctx, in, opts...)
-}
\ No newline at end of file
+}
+
+func (c *ProductClient) ReserveStock(ctx context.Context, in *proto.ReserveStockRequest, opts ...grpc.CallOption) (*proto.ReserveStockResponse, error) {
+	return c.client.ReserveStock(ctx, in, opts...)
+}
+
+func (c *ProductClient) ReleaseStock(ctx context.Context, in *proto.ReleaseStockRequest, opts ...grpc.CallOption) (*proto.ReleaseStockResponse, error) {
+	return c.client.ReleaseStock(ctx, in, opts...)
+}
+
+func (c *ProductClient) ListTags(ctx context.Context, in *proto.ListTagsRequest, opts ...grpc.CallOption) (*proto.ListTagsResponse, error) {
+	return c.client.ListTags(ctx, in, opts...)
+}
+
+func (c *ProductClient) CreateCategory(ctx context.Context, in *proto.CreateCategoryRequest, opts ...grpc.CallOption) (*proto.Category, error) {
+	return c.client.CreateCategory(ctx, in, opts...)
+}
+
+func (c *ProductClient) GetCategory(ctx context.Context, in *proto.GetCategoryRequest, opts ...grpc.CallOption) (*proto.Category, error) {
+	return c.client.GetCategory(ctx, in, opts...)
+}
+
+func (c *ProductClient) UpdateCategory(ctx context.Context, in *proto.Category, opts ...grpc.CallOption) (*proto.Category, error) {
+	return c.client.UpdateCategory(ctx, in, opts...)
+}
+
+func (c *ProductClient) DeleteCategory(ctx context.Context, in *proto.DeleteCategoryRequest, opts ...grpc.CallOption) (*proto.DeleteCategoryResponse, error) {
+	return c.client.DeleteCategory(ctx, in, opts...)
+}
+
+func (c *ProductClient) ListCategories(ctx context.Context, in *proto.ListCategoriesRequest, opts ...grpc.CallOption) (*proto.ListCategoriesResponse, error) {
+	return c.client.ListCategories(ctx, in, opts...)
+}
@@ -1,8 +1,12 @@
 package grpc
 
 import (
+	"FoodStore-AdvProg2/domain"
 	"FoodStore-AdvProg2/proto"
 	"context"
+	"encoding/base64"
+	"log"
+
 	"google.golang.org/grpc"
 )
 
@@ -11,7 +15,7 @@ type UserClient struct {
 }
 
 func NewUserClient(addr string) (*UserClient, *grpc.ClientConn) {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	conn, err := grpc.Dial(addr, DialOptions()...)
 	if err != nil {
 		panic(err)
 	}
@@ -33,4 +37,48 @@ func (c *UserClient) GetProfile(ctx context.Context, in *proto.GetProfileRequest
 
 func (c *UserClient) ValidateToken(ctx context.Context, in *proto.ValidateTokenRequest, opts ...grpc.CallOption) (*proto.ValidateTokenResponse, error) {
 	return c.client.ValidateToken(ctx, in, opts...)
-}
\ No newline at end of file
+}
+
+func (c *UserClient) SetUserRole(ctx context.Context, in *proto.SetUserRoleRequest, opts ...grpc.CallOption) (*proto.SetUserRoleResponse, error) {
+	return c.client.SetUserRole(ctx, in, opts...)
+}
+
+func (c *UserClient) RevokeToken(ctx context.Context, in *proto.RevokeTokenRequest, opts ...grpc.CallOption) (*proto.RevokeTokenResponse, error) {
+	return c.client.RevokeToken(ctx, in, opts...)
+}
+
+func (c *UserClient) RevokeAllTokens(ctx context.Context, in *proto.RevokeAllTokensRequest, opts ...grpc.CallOption) (*proto.RevokeAllTokensResponse, error) {
+	return c.client.RevokeAllTokens(ctx, in, opts...)
+}
+
+func (c *UserClient) RefreshToken(ctx context.Context, in *proto.RefreshTokenRequest, opts ...grpc.CallOption) (*proto.RefreshTokenResponse, error) {
+	return c.client.RefreshToken(ctx, in, opts...)
+}
+
+func (c *UserClient) GetSigningKeys(ctx context.Context, in *proto.GetSigningKeysRequest, opts ...grpc.CallOption) (*proto.GetSigningKeysResponse, error) {
+	return c.client.GetSigningKeys(ctx, in, opts...)
+}
+
+// FetchSigningKeys loads the public keys that verify access tokens from the
+// user service.
+func FetchSigningKeys(client proto.UserServiceClient) ([]domain.SigningKey, error) {
+	resp, err := client.GetSigningKeys(context.Background(), &proto.GetSigningKeysRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]domain.SigningKey, 0, len(resp.Keys))
+	for _, key := range resp.Keys {
+		publicKey, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil {
+			log.Printf("Skipping signing key %s with malformed public key: %v", key.Kid, err)
+			continue
+		}
+		keys = append(keys, domain.SigningKey{
+			KeyID:     key.Kid,
+			Algorithm: key.Alg,
+			PublicKey: publicKey,
+		})
+	}
+	return keys, nil
+}
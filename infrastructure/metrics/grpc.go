@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	grpcServerHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "foodstore_grpc_server_handled_total",
+		Help: "RPCs completed on the server, by method and status code.",
+	}, []string{"service", "method", "code"})
+	grpcServerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "foodstore_grpc_server_handling_seconds",
+		Help:    "Time the server took to complete RPCs, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"service", "method"})
+
+	grpcClientHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "foodstore_grpc_client_handled_total",
+		Help: "RPCs completed by clients, by method and status code.",
+	}, []string{"service", "method", "code"})
+	grpcClientDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "foodstore_grpc_client_handling_seconds",
+		Help:    "Time clients waited for RPCs to complete, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"service", "method"})
+)
+
+// splitMethod splits a full method name such as /order.OrderService/GetOrder
+// into its service and method.
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", fullMethod
+	}
+	return service, method
+}
+
+func observe(handled *prometheus.CounterVec, duration *prometheus.HistogramVec, fullMethod string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	handled.WithLabelValues(service, method, status.Code(err).String()).Inc()
+	duration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+}
+
+func UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe(grpcServerHandled, grpcServerDuration, info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamServerInterceptor records a stream when it ends, timing its whole
+// life.
+func StreamServerInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	observe(grpcServerHandled, grpcServerDuration, info.FullMethod, start, err)
+	return err
+}
+
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	observe(grpcClientHandled, grpcClientDuration, method, start, err)
+	return err
+}
+
+// StreamClientInterceptor records a stream when receiving from it first
+// fails; io.EOF counts as OK.
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		observe(grpcClientHandled, grpcClientDuration, method, start, err)
+		return nil, err
+	}
+	return &clientStream{ClientStream: stream, method: method, start: start}, nil
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	method string
+	start  time.Time
+	once   sync.Once
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() {
+			result := err
+			if errors.Is(err, io.EOF) {
+				result = nil
+			}
+			observe(grpcClientHandled, grpcClientDuration, s.method, s.start, result)
+		})
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+// Package metrics defines the Prometheus metrics exported by the FoodStore
+// binaries, and the interceptors, collectors and sinks that record them. All
+// metrics live in the default registry, so services run in one process share
+// one /metrics endpoint.
+package metrics
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "foodstore_http_requests_total",
+		Help: "HTTP requests handled by the gateway, by route and status.",
+	}, []string{"method", "route", "status"})
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "foodstore_http_request_duration_seconds",
+		Help:    "Time the gateway took to handle HTTP requests, by route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	ordersCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "foodstore_orders_created_total",
+		Help: "Orders created.",
+	})
+	ordersCancelled = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "foodstore_orders_cancelled_total",
+		Help: "Orders moved to the cancelled status.",
+	})
+	stockOuts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "foodstore_stock_outs_total",
+		Help: "Stock reservations and decrements rejected for insufficient stock.",
+	})
+)
+
+// Handler serves every registered metric in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveHTTPRequest records one request handled by the gateway. route is the
+// matched route pattern, such as /api/orders/:id, so that IDs do not create
+// a series each.
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// StockOut records a stock change rejected for insufficient stock.
+func StockOut() {
+	stockOuts.Inc()
+}
+
+// OrderEvents counts created and cancelled orders from the outbox events the
+// relay delivers. Only an event's first delivery attempt is counted, so an
+// event retried because another sink failed is not counted twice.
+var OrderEvents orderEventSink
+
+type orderEventSink struct{}
+
+func (orderEventSink) Publish(event domain.OrderEvent) error {
+	if event.Attempts > 1 {
+		return nil
+	}
+
+	switch event.Type {
+	case domain.EventOrderCreated:
+		ordersCreated.Inc()
+	case domain.EventOrderStatusChanged:
+		var payload domain.OrderEventPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			// Failing would only make the relay retry an event it cannot read.
+			log.Printf("Failed to read payload of event %d for metrics: %v", event.ID, err)
+			return nil
+		}
+		if payload.Status == domain.OrderStatusCancelled {
+			ordersCancelled.Inc()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"context"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type CartPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCartPostgresRepo() *CartPostgresRepo {
+	return &CartPostgresRepo{db: DB}
+}
+
+func (r *CartPostgresRepo) FindItems(userID string) ([]domain.CartItem, error) {
+	ctx := context.Background()
+	rows, err := r.db.Query(ctx, `
+		SELECT product_id, quantity, unit_price_amount, currency, added_at
+		FROM cart_items
+		WHERE user_id = $1
+		ORDER BY added_at, product_id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.CartItem
+	for rows.Next() {
+		var item domain.CartItem
+		if err := rows.Scan(&item.ProductID, &item.Quantity, &item.Price.Amount, &item.Price.Currency, &item.AddedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// SaveItem inserts a cart line or replaces the quantity and price of an
+// existing one, keeping its original position in the cart.
+func (r *CartPostgresRepo) SaveItem(userID string, item domain.CartItem) error {
+	ctx := context.Background()
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO cart_items (user_id, product_id, quantity, unit_price_amount, currency, added_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, product_id)
+		DO UPDATE SET quantity = EXCLUDED.quantity, unit_price_amount = EXCLUDED.unit_price_amount, currency = EXCLUDED.currency`,
+		userID, item.ProductID, item.Quantity, item.Price.Amount, item.Price.Currency, item.AddedAt)
+	return err
+}
+
+func (r *CartPostgresRepo) RemoveItem(userID, productID string) (bool, error) {
+	ctx := context.Background()
+	result, err := r.db.Exec(ctx, `
+		DELETE FROM cart_items WHERE user_id = $1 AND product_id = $2`, userID, productID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (r *CartPostgresRepo) Clear(userID string) error {
+	ctx := context.Background()
+	_, err := r.db.Exec(ctx, `DELETE FROM cart_items WHERE user_id = $1`, userID)
+	return err
+}
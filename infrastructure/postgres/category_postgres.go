@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type CategoryPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCategoryPostgresRepo() *CategoryPostgresRepo {
+	return &CategoryPostgresRepo{db: DB}
+}
+
+// isUniqueViolation reports whether err is PostgreSQL's unique_violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func (r *CategoryPostgresRepo) Save(category domain.Category) error {
+	_, err := r.db.Exec(context.Background(), `
+		INSERT INTO categories (id, name, parent_id)
+		VALUES ($1, $2, NULLIF($3, '')::uuid)`,
+		category.ID, category.Name, category.ParentID)
+	if isUniqueViolation(err) {
+		return domain.ErrCategoryExists
+	}
+	return err
+}
+
+func (r *CategoryPostgresRepo) FindByID(id string) (domain.Category, error) {
+	var category domain.Category
+	err := r.db.QueryRow(context.Background(), `
+		SELECT id, name, COALESCE(parent_id::text, '')
+		FROM categories
+		WHERE id = $1`, id).
+		Scan(&category.ID, &category.Name, &category.ParentID)
+	if err == pgx.ErrNoRows {
+		return domain.Category{}, domain.ErrCategoryNotFound
+	}
+	return category, err
+}
+
+func (r *CategoryPostgresRepo) FindAll() ([]domain.Category, error) {
+	rows, err := r.db.Query(context.Background(), `
+		SELECT id, name, COALESCE(parent_id::text, '')
+		FROM categories
+		ORDER BY LOWER(name), id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []domain.Category
+	for rows.Next() {
+		var category domain.Category
+		if err := rows.Scan(&category.ID, &category.Name, &category.ParentID); err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+func (r *CategoryPostgresRepo) Update(category domain.Category) error {
+	result, err := r.db.Exec(context.Background(), `
+		UPDATE categories
+		SET name = $1, parent_id = NULLIF($2, '')::uuid
+		WHERE id = $3`,
+		category.Name, category.ParentID, category.ID)
+	if isUniqueViolation(err) {
+		return domain.ErrCategoryExists
+	}
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// Delete removes a category. Products in it are left without a category.
+func (r *CategoryPostgresRepo) Delete(id string) error {
+	result, err := r.db.Exec(context.Background(), `DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+func (r *CategoryPostgresRepo) HasChildren(id string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(context.Background(),
+		`SELECT EXISTS(SELECT 1 FROM categories WHERE parent_id = $1)`, id).Scan(&exists)
+	return exists, err
+}
@@ -36,4 +36,4 @@ func InitDB(dbHost string) (*pgxpool.Pool, error) {
 
     log.Println("Database connection established")
     return db, nil
-}
\ No newline at end of file
+}
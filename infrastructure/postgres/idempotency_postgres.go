@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// claimAttempts bounds how often Claim retries when the key it conflicted
+// with is released before it can be read.
+const claimAttempts = 3
+
+type IdempotencyPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyPostgresRepo() *IdempotencyPostgresRepo {
+	return &IdempotencyPostgresRepo{db: DB}
+}
+
+// Claim stores the record unless the key is already taken. It returns the
+// stored record and whether this call claimed it. A claim for the same
+// request that has had no order for longer than lease is taken over, since
+// the request that made it most likely died before creating one.
+func (r *IdempotencyPostgresRepo) Claim(record domain.IdempotencyRecord, lease time.Duration) (domain.IdempotencyRecord, bool, error) {
+	ctx := context.Background()
+	for attempt := 0; attempt < claimAttempts; attempt++ {
+		result, err := r.db.Exec(ctx, `
+			INSERT INTO idempotency_keys (user_id, key, request_hash, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, key) DO UPDATE SET created_at = EXCLUDED.created_at
+			WHERE idempotency_keys.order_id IS NULL
+			  AND idempotency_keys.request_hash = EXCLUDED.request_hash
+			  AND idempotency_keys.created_at < $5`,
+			record.UserID, record.Key, record.RequestHash, record.CreatedAt, record.CreatedAt.Add(-lease))
+		if err != nil {
+			return domain.IdempotencyRecord{}, false, err
+		}
+		if result.RowsAffected() == 1 {
+			return record, true, nil
+		}
+
+		var existing domain.IdempotencyRecord
+		err = r.db.QueryRow(ctx, `
+			SELECT user_id, key, request_hash, COALESCE(order_id::text, ''), created_at
+			FROM idempotency_keys
+			WHERE user_id = $1 AND key = $2`, record.UserID, record.Key).
+			Scan(&existing.UserID, &existing.Key, &existing.RequestHash, &existing.OrderID, &existing.CreatedAt)
+		if err == pgx.ErrNoRows {
+			// The other request released the key between our insert and select.
+			continue
+		}
+		if err != nil {
+			return domain.IdempotencyRecord{}, false, err
+		}
+		return existing, false, nil
+	}
+	return domain.IdempotencyRecord{}, false, domain.ErrIdempotencyKeyInProgress
+}
+
+// FindOrderID returns the order created under a key, or "" when the key is
+// not claimed or its request has not saved an order yet.
+func (r *IdempotencyPostgresRepo) FindOrderID(userID, key string) (string, error) {
+	var orderID string
+	err := r.db.QueryRow(context.Background(), `
+		SELECT COALESCE(order_id::text, '')
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2`, userID, key).Scan(&orderID)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	return orderID, err
+}
+
+// completeIdempotencyKey points a claimed key at the order created for it. It
+// runs in the transaction that saves the order, so a key never outlives a
+// crash without the order it produced. It fails if the claim was taken over
+// or its order was already recorded.
+func completeIdempotencyKey(ctx context.Context, tx pgx.Tx, userID, key, orderID string) error {
+	result, err := tx.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET order_id = $1
+		WHERE user_id = $2 AND key = $3 AND order_id IS NULL`, orderID, userID, key)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrIdempotencyKeyInProgress
+	}
+	return nil
+}
+
+// Release frees a key whose request failed before creating an order.
+func (r *IdempotencyPostgresRepo) Release(userID, key string) error {
+	ctx := context.Background()
+	_, err := r.db.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2 AND order_id IS NULL`, userID, key)
+	return err
+}
+
+// ReleaseOrder frees the key an order was created under, once the order has
+// failed, so that retrying the request places a new order.
+func (r *IdempotencyPostgresRepo) ReleaseOrder(orderID string) error {
+	ctx := context.Background()
+	_, err := r.db.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE order_id = $1`, orderID)
+	return err
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"fmt"
+)
+
+// keyset pages a listing ordered by column and then by id. Without a cursor
+// pages are read by offset. With one, (column, id) is compared against the
+// cursor's row so the index is used instead of skipping rows. A page before
+// the cursor is read in reverse order and flipped back afterwards.
+type keyset struct {
+	column   string // empty for listings ordered by id only
+	desc     bool
+	cursor   *domain.Cursor
+	backward bool
+}
+
+func newKeyset(column string, sort domain.SortParams, cursor *domain.Cursor) keyset {
+	backward := cursor != nil && cursor.Before
+	return keyset{column: column, desc: sort.Desc != backward, cursor: cursor, backward: backward}
+}
+
+// condition returns the AND condition that starts the page after (or ends it
+// before) the cursor's row, whose sort value is value.
+func (k keyset) condition(value interface{}, argCount int) (string, []interface{}, int) {
+	op := ">"
+	if k.desc {
+		op = "<"
+	}
+	if k.column == "" {
+		return fmt.Sprintf(" AND id %s $%d", op, argCount), []interface{}{k.cursor.ID}, argCount + 1
+	}
+	return fmt.Sprintf(" AND (%s, id) %s ($%d, $%d)", k.column, op, argCount, argCount+1),
+		[]interface{}{value, k.cursor.ID}, argCount + 2
+}
+
+// limit returns the ORDER BY, LIMIT and OFFSET clauses. One row more than the
+// page size is read to tell whether the listing continues.
+func (k keyset) limit(perPage, offset, argCount int) (string, []interface{}) {
+	dir := "ASC"
+	if k.desc {
+		dir = "DESC"
+	}
+	order := "id " + dir
+	if k.column != "" {
+		order = k.column + " " + dir + ", " + order
+	}
+	if k.cursor != nil {
+		return fmt.Sprintf(" ORDER BY %s LIMIT $%d", order, argCount), []interface{}{perPage + 1}
+	}
+	return fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", order, argCount, argCount+1),
+		[]interface{}{perPage + 1, offset}
+}
+
+// keysetPage trims rows read with limit to the page size and puts them back in
+// listing order. It reports whether rows exist after the last row and before
+// the first one.
+func keysetPage[T any](k keyset, rows []T, perPage, offset int) (page []T, hasNext, hasPrev bool) {
+	more := len(rows) > perPage
+	if more {
+		rows = rows[:perPage]
+	}
+	if k.backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+		return rows, true, more
+	}
+	return rows, more, k.cursor != nil || offset > 0
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"reflect"
+	"testing"
+)
+
+func TestKeysetCondition(t *testing.T) {
+	asc := domain.SortParams{By: "price"}
+	desc := domain.SortParams{By: "price", Desc: true}
+	tests := []struct {
+		name     string
+		column   string
+		sort     domain.SortParams
+		cursor   domain.Cursor
+		wantSQL  string
+		wantArgs []interface{}
+		wantNext int
+	}{
+		{"after ascending", "price", asc, domain.Cursor{ID: "b"},
+			" AND (price, id) > ($3, $4)", []interface{}{int64(500), "b"}, 5},
+		{"after descending", "price", desc, domain.Cursor{ID: "b"},
+			" AND (price, id) < ($3, $4)", []interface{}{int64(500), "b"}, 5},
+		{"before ascending", "price", asc, domain.Cursor{ID: "b", Before: true},
+			" AND (price, id) < ($3, $4)", []interface{}{int64(500), "b"}, 5},
+		{"before descending", "price", desc, domain.Cursor{ID: "b", Before: true},
+			" AND (price, id) > ($3, $4)", []interface{}{int64(500), "b"}, 5},
+		{"id only", "", domain.SortParams{}, domain.Cursor{ID: "b"},
+			" AND id > $3", []interface{}{"b"}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := newKeyset(tt.column, tt.sort, &tt.cursor)
+			sql, args, next := k.condition(int64(500), 3)
+			if sql != tt.wantSQL || !reflect.DeepEqual(args, tt.wantArgs) || next != tt.wantNext {
+				t.Errorf("condition = %q, %v, %d; want %q, %v, %d", sql, args, next, tt.wantSQL, tt.wantArgs, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestKeysetLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		column   string
+		sort     domain.SortParams
+		cursor   *domain.Cursor
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{"offset", "created_at", domain.SortParams{Desc: true}, nil,
+			" ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3", []interface{}{11, 20}},
+		{"cursor", "created_at", domain.SortParams{Desc: true}, &domain.Cursor{ID: "a"},
+			" ORDER BY created_at DESC, id DESC LIMIT $2", []interface{}{11}},
+		{"cursor before reads in reverse", "created_at", domain.SortParams{Desc: true}, &domain.Cursor{ID: "a", Before: true},
+			" ORDER BY created_at ASC, id ASC LIMIT $2", []interface{}{11}},
+		{"id only", "", domain.SortParams{}, nil,
+			" ORDER BY id ASC LIMIT $2 OFFSET $3", []interface{}{11, 20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := newKeyset(tt.column, tt.sort, tt.cursor).limit(10, 20, 2)
+			if sql != tt.wantSQL || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("limit = %q, %v; want %q, %v", sql, args, tt.wantSQL, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   *domain.Cursor
+		rows     []int
+		offset   int
+		want     []int
+		wantNext bool
+		wantPrev bool
+	}{
+		{"first page, more follow", nil, []int{1, 2, 3, 4}, 0, []int{1, 2, 3}, true, false},
+		{"first page, last", nil, []int{1, 2}, 0, []int{1, 2}, false, false},
+		{"offset page", nil, []int{4, 5, 6}, 3, []int{4, 5, 6}, false, true},
+		{"after cursor, more follow", &domain.Cursor{ID: "3"}, []int{4, 5, 6, 7}, 0, []int{4, 5, 6}, true, true},
+		{"after cursor, last", &domain.Cursor{ID: "3"}, []int{4}, 0, []int{4}, false, true},
+		{"before cursor, more precede", &domain.Cursor{ID: "7", Before: true}, []int{6, 5, 4, 3}, 0, []int{4, 5, 6}, true, true},
+		{"before cursor, first", &domain.Cursor{ID: "3", Before: true}, []int{2, 1}, 0, []int{1, 2}, true, false},
+		{"empty", nil, nil, 0, nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := newKeyset("created_at", domain.SortParams{}, tt.cursor)
+			page, hasNext, hasPrev := keysetPage(k, tt.rows, 3, tt.offset)
+			if !reflect.DeepEqual(page, tt.want) || hasNext != tt.wantNext || hasPrev != tt.wantPrev {
+				t.Errorf("keysetPage = %v, %v, %v; want %v, %v, %v", page, hasNext, hasPrev, tt.want, tt.wantNext, tt.wantPrev)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+// Each service owns its database and keeps its migrations in
+// migrations/<service>.
+const (
+	ServiceOrders    = "orders"
+	ServiceUsers     = "users"
+	ServiceInventory = "inventory"
+)
+
+var Services = []string{ServiceOrders, ServiceUsers, ServiceInventory}
+
+// migrationLockID is the pg_advisory_lock key held while migrations run, so
+// services starting at the same time do not apply the same migration twice.
+const migrationLockID int64 = 7_142_031_901
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+var (
+	ErrNoMigrations   = errors.New("no migrations to roll back")
+	ErrUnknownService = errors.New("unknown service")
+)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	db         *pgxpool.Pool
+	service    string
+	migrations []Migration
+}
+
+func NewMigrator(db *pgxpool.Pool, service string) (*Migrator, error) {
+	if !isService(service) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownService, service)
+	}
+	migrations, err := LoadMigrations(migrationFiles, path.Join("migrations", service))
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, service: service, migrations: migrations}, nil
+}
+
+func isService(service string) bool {
+	for _, s := range Services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadMigrations reads <version>_<name>.up.sql and .down.sql pairs from dir
+// and returns them ordered by version.
+func LoadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file name %q", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %q: %w", entry.Name(), err)
+		}
+		body, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// withLock runs fn on a single connection holding the migration advisory
+// lock, after making sure the schema_migrations table exists. Versions are
+// recorded per service so services can still share one database. Rows from
+// before the split are kept under the "shared" service and ignored; every
+// migration is written to be safe to apply over them.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			log.Printf("Failed to release migration lock: %v", err)
+		}
+	}()
+
+	_, err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			service VARCHAR(50) NOT NULL,
+			version BIGINT NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (service, version)
+		);
+		DO $$
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = 'schema_migrations' AND column_name = 'service'
+			) THEN
+				ALTER TABLE schema_migrations ADD COLUMN service VARCHAR(50) NOT NULL DEFAULT 'shared';
+				ALTER TABLE schema_migrations ALTER COLUMN service DROP DEFAULT;
+				ALTER TABLE schema_migrations DROP CONSTRAINT schema_migrations_pkey;
+				ALTER TABLE schema_migrations ADD PRIMARY KEY (service, version);
+			END IF;
+		END $$;`)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) appliedMigrations(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations WHERE service = $1`, m.service)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// apply runs one migration and records it in a single transaction, so a
+// failing migration leaves neither its changes nor its version behind.
+func (m *Migrator) apply(ctx context.Context, conn *pgxpool.Conn, migration Migration, up bool) error {
+	return conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if up {
+			if _, err := tx.Exec(ctx, migration.Up); err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+			_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (service, version, name) VALUES ($1, $2, $3)`,
+				m.service, migration.Version, migration.Name)
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, migration.Down); err != nil {
+			return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+		}
+		_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE service = $1 AND version = $2`, m.service, migration.Version)
+		return err
+	})
+}
+
+// Up applies every pending migration in version order and returns how many
+// were applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			log.Printf("Applied %s migration %d_%s", m.service, migration.Version, migration.Name)
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down rolls back the most recently applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			log.Printf("Rolled back %s migration %d_%s", m.service, migration.Version, migration.Name)
+			count++
+		}
+		if count == 0 && steps > 0 {
+			return ErrNoMigrations
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Redo rolls back the latest applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			if err := m.apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			log.Printf("Redid %s migration %d_%s", m.service, migration.Version, migration.Name)
+			return nil
+		}
+		return ErrNoMigrations
+	})
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := m.appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			appliedAt, ok := applied[migration.Version]
+			statuses = append(statuses, MigrationStatus{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Applied:   ok,
+				AppliedAt: appliedAt,
+			})
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// RunMigrations brings the service's schema on DB up to date. Services call
+// it on startup; cmd/migrate exposes the other operations.
+func RunMigrations(service string) error {
+	migrator, err := NewMigrator(DB, service)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		log.Printf("Error running %s migrations: %v", service, err)
+		return err
+	}
+	log.Printf("Database schema for %s up to date, %d migrations applied", service, applied)
+	return nil
+}
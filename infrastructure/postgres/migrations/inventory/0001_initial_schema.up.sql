@@ -0,0 +1,22 @@
+-- Tables are created with IF NOT EXISTS so databases set up by the old
+-- InitTables can adopt migrations without being recreated.
+CREATE TABLE IF NOT EXISTS products (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    price DECIMAL(10, 2) NOT NULL,
+    stock INT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    reference VARCHAR(255) PRIMARY KEY,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    released_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS stock_reservation_lines (
+    reference VARCHAR(255) NOT NULL REFERENCES stock_reservations(reference) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    quantity INT NOT NULL,
+    PRIMARY KEY (reference, product_id)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE products ADD COLUMN price DECIMAL(10, 2);
+UPDATE products SET price = price_amount / 100.0;
+ALTER TABLE products ALTER COLUMN price SET NOT NULL;
+ALTER TABLE products DROP COLUMN price_amount;
+ALTER TABLE products DROP COLUMN currency;
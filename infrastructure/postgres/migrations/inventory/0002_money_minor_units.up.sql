@@ -0,0 +1,16 @@
+-- Prices move from DECIMAL major units to BIGINT minor units plus an ISO 4217
+-- currency. Existing amounts are in KZT. Each block is skipped when the
+-- DECIMAL column is already gone.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'products' AND column_name = 'price'
+    ) THEN
+        ALTER TABLE products ADD COLUMN IF NOT EXISTS price_amount BIGINT;
+        UPDATE products SET price_amount = ROUND(price * 100);
+        ALTER TABLE products ALTER COLUMN price_amount SET NOT NULL;
+        ALTER TABLE products DROP COLUMN price;
+    END IF;
+END $$;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'KZT';
@@ -0,0 +1,5 @@
+-- Products are archived instead of deleted so orders and reservations that
+-- mention them keep pointing at a real row.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS products_active_idx ON products (id) WHERE archived_at IS NULL;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS product_allergens;
+DROP TABLE IF EXISTS product_tags;
+DROP INDEX IF EXISTS products_category_id_idx;
+ALTER TABLE products DROP COLUMN IF EXISTS category_id;
+ALTER TABLE products DROP COLUMN IF EXISTS unit;
+ALTER TABLE products DROP COLUMN IF EXISTS image_url;
+ALTER TABLE products DROP COLUMN IF EXISTS description;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    parent_id UUID REFERENCES categories(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Sibling categories need distinct names; top-level categories are siblings
+-- of each other.
+CREATE UNIQUE INDEX IF NOT EXISTS categories_parent_name_idx
+    ON categories (COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'), LOWER(name));
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS image_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS unit VARCHAR(20) NOT NULL DEFAULT 'piece';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS products_category_id_idx ON products (category_id);
+
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (product_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS product_tags_tag_idx ON product_tags (tag);
+
+CREATE TABLE IF NOT EXISTS product_allergens (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    allergen VARCHAR(50) NOT NULL,
+    PRIMARY KEY (product_id, allergen)
+);
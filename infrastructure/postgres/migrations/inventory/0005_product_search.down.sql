@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS products_name_trgm_idx;
+DROP INDEX IF EXISTS products_search_vector_idx;
+DROP TRIGGER IF EXISTS product_tags_search_vector_update ON product_tags;
+DROP FUNCTION IF EXISTS product_tags_search_vector_trigger();
+DROP TRIGGER IF EXISTS products_search_vector_update ON products;
+DROP FUNCTION IF EXISTS products_search_vector_trigger();
+DROP FUNCTION IF EXISTS product_search_vector(UUID, TEXT, TEXT);
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
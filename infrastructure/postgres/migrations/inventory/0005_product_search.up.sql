@@ -0,0 +1,49 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- search_vector weighs the name highest, then tags, then the description. It
+-- is kept up to date by triggers on products and product_tags.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector TSVECTOR NOT NULL DEFAULT ''::tsvector;
+
+-- product_search_vector(id, name, description)
+CREATE OR REPLACE FUNCTION product_search_vector(UUID, TEXT, TEXT)
+RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector('english', $2), 'A')
+        || setweight(to_tsvector('english', COALESCE(
+            (SELECT string_agg(tag, ' ') FROM product_tags t WHERE t.product_id = $1), '')), 'B')
+        || setweight(to_tsvector('english', $3), 'C')
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION products_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := product_search_vector(NEW.id, NEW.name, NEW.description);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS products_search_vector_update ON products;
+CREATE TRIGGER products_search_vector_update
+    BEFORE INSERT OR UPDATE OF name, description ON products
+    FOR EACH ROW EXECUTE FUNCTION products_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION product_tags_search_vector_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    changed_id UUID := CASE WHEN TG_OP = 'DELETE' THEN OLD.product_id ELSE NEW.product_id END;
+BEGIN
+    UPDATE products
+    SET search_vector = product_search_vector(id, name, description)
+    WHERE id = changed_id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS product_tags_search_vector_update ON product_tags;
+CREATE TRIGGER product_tags_search_vector_update
+    AFTER INSERT OR DELETE ON product_tags
+    FOR EACH ROW EXECUTE FUNCTION product_tags_search_vector_trigger();
+
+UPDATE products SET search_vector = product_search_vector(id, name, description);
+
+CREATE INDEX IF NOT EXISTS products_search_vector_idx ON products USING GIN (search_vector);
+
+-- Trigram index for typo-tolerant matching on product names.
+CREATE INDEX IF NOT EXISTS products_name_trgm_idx ON products USING GIN (name gin_trgm_ops);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS products_created_at_id_idx;
+DROP INDEX IF EXISTS products_stock_id_idx;
+DROP INDEX IF EXISTS products_name_id_idx;
+DROP INDEX IF EXISTS products_price_id_idx;
+ALTER TABLE products DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,10 @@
+-- Existing products all get the migration time as created_at; ties are
+-- broken by id.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Keyset pagination compares (sort column, id) pairs, so each sort key gets
+-- a composite index that can be scanned in either direction.
+CREATE INDEX IF NOT EXISTS products_price_id_idx ON products (price_amount, id);
+CREATE INDEX IF NOT EXISTS products_name_id_idx ON products (name, id);
+CREATE INDEX IF NOT EXISTS products_stock_id_idx ON products (stock, id);
+CREATE INDEX IF NOT EXISTS products_created_at_id_idx ON products (created_at, id);
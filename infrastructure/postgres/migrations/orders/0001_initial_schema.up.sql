@@ -0,0 +1,17 @@
+-- Tables are created with IF NOT EXISTS so databases set up by the old
+-- InitTables can adopt migrations without being recreated.
+CREATE TABLE IF NOT EXISTS orders (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    total_price DECIMAL(10, 2) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_id UUID,
+    quantity INT NOT NULL,
+    price DECIMAL(10, 2) NOT NULL
+);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS order_sagas (
+    id UUID PRIMARY KEY,
+    saga_type VARCHAR(50) NOT NULL,
+    order_id UUID NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    completed_steps TEXT[] NOT NULL DEFAULT '{}',
+    payload JSONB,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx
+    ON outbox (next_attempt_at)
+    WHERE delivered_at IS NULL;
@@ -0,0 +1,19 @@
+-- Amounts in currencies other than KZT cannot be represented after this and
+-- are converted as if they were KZT.
+ALTER TABLE orders ADD COLUMN total_price DECIMAL(10, 2);
+UPDATE orders SET total_price = total_amount / 100.0;
+ALTER TABLE orders ALTER COLUMN total_price SET NOT NULL;
+ALTER TABLE orders DROP COLUMN total_amount;
+ALTER TABLE orders DROP COLUMN currency;
+
+ALTER TABLE order_items ADD COLUMN price DECIMAL(10, 2);
+UPDATE order_items SET price = price_amount / 100.0;
+ALTER TABLE order_items ALTER COLUMN price SET NOT NULL;
+ALTER TABLE order_items DROP COLUMN price_amount;
+ALTER TABLE order_items DROP COLUMN currency;
+
+ALTER TABLE cart_items ADD COLUMN unit_price DECIMAL(10, 2);
+UPDATE cart_items SET unit_price = unit_price_amount / 100.0;
+ALTER TABLE cart_items ALTER COLUMN unit_price SET NOT NULL;
+ALTER TABLE cart_items DROP COLUMN unit_price_amount;
+ALTER TABLE cart_items DROP COLUMN currency;
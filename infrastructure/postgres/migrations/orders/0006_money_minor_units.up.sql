@@ -0,0 +1,44 @@
+-- Prices move from DECIMAL major units to BIGINT minor units plus an ISO 4217
+-- currency. Existing amounts are in KZT. Each block is skipped when the
+-- DECIMAL column is already gone.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'orders' AND column_name = 'total_price'
+    ) THEN
+        ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_amount BIGINT;
+        UPDATE orders SET total_amount = ROUND(total_price * 100);
+        ALTER TABLE orders ALTER COLUMN total_amount SET NOT NULL;
+        ALTER TABLE orders DROP COLUMN total_price;
+    END IF;
+END $$;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'KZT';
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'order_items' AND column_name = 'price'
+    ) THEN
+        ALTER TABLE order_items ADD COLUMN IF NOT EXISTS price_amount BIGINT;
+        UPDATE order_items SET price_amount = ROUND(price * 100);
+        ALTER TABLE order_items ALTER COLUMN price_amount SET NOT NULL;
+        ALTER TABLE order_items DROP COLUMN price;
+    END IF;
+END $$;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'KZT';
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'cart_items' AND column_name = 'unit_price'
+    ) THEN
+        ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS unit_price_amount BIGINT;
+        UPDATE cart_items SET unit_price_amount = ROUND(unit_price * 100);
+        ALTER TABLE cart_items ALTER COLUMN unit_price_amount SET NOT NULL;
+        ALTER TABLE cart_items DROP COLUMN unit_price;
+    END IF;
+END $$;
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'KZT';
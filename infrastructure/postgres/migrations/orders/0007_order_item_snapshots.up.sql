@@ -0,0 +1,17 @@
+-- Products live in the inventory service's database, so order items can no
+-- longer reference them. Each item keeps its own copy of the product name
+-- next to the price it already records.
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS order_items_product_id_fkey;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS product_name VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Databases still shared with the inventory service can fill in names for
+-- existing items.
+DO $$
+BEGIN
+    IF to_regclass('products') IS NOT NULL THEN
+        UPDATE order_items oi
+        SET product_name = p.name
+        FROM products p
+        WHERE p.id = oi.product_id AND oi.product_name = '';
+    END IF;
+END $$;
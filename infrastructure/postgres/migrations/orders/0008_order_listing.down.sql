@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS orders_total_amount_id_idx;
+DROP INDEX IF EXISTS orders_user_created_at_id_idx;
+DROP INDEX IF EXISTS orders_created_at_id_idx;
+ALTER TABLE orders ALTER COLUMN created_at DROP NOT NULL;
@@ -0,0 +1,8 @@
+-- Keyset pagination compares (created_at, id) pairs, which needs created_at
+-- to be set on every order.
+UPDATE orders SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+ALTER TABLE orders ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS orders_created_at_id_idx ON orders (created_at, id);
+CREATE INDEX IF NOT EXISTS orders_user_created_at_id_idx ON orders (user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS orders_total_amount_id_idx ON orders (total_amount, id);
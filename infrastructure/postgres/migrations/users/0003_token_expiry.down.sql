@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tokens_user_id_idx;
+DROP INDEX IF EXISTS tokens_expires_at_idx;
+ALTER TABLE tokens DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,6 @@
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+UPDATE tokens SET expires_at = created_at + INTERVAL '24 hours' WHERE expires_at IS NULL;
+ALTER TABLE tokens ALTER COLUMN expires_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS tokens_expires_at_idx ON tokens (expires_at);
+CREATE INDEX IF NOT EXISTS tokens_user_id_idx ON tokens (user_id);
@@ -3,7 +3,7 @@ package postgres
 import (
 	"FoodStore-AdvProg2/domain"
 	"context"
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,16 +21,13 @@ func NewOrderPostgresRepo() *OrderPostgresRepo {
 
 func (r *OrderPostgresRepo) Save(order domain.Order, items []domain.OrderItem) (string, error) {
 	ctx := context.Background()
-	orderID := uuid.New().String()
-	createdAt := time.Now()
-
-	var userExists bool
-	err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`, order.UserID).Scan(&userExists)
-	if err != nil {
-		return "", err
+	orderID := order.ID
+	if orderID == "" {
+		orderID = uuid.New().String()
 	}
-	if !userExists {
-		return "", errors.New("user does not exist")
+	createdAt := order.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
 	}
 
 	tx, err := r.db.Begin(ctx)
@@ -44,28 +41,19 @@ func (r *OrderPostgresRepo) Save(order domain.Order, items []domain.OrderItem) (
 	}()
 
 	_, err = tx.Exec(ctx, `
-		INSERT INTO orders (id, user_id, total_price, status, created_at)
-		VALUES ($1, $2, $3, $4, $5)`,
-		orderID, order.UserID, order.TotalPrice, order.Status, createdAt)
+		INSERT INTO orders (id, user_id, total_amount, currency, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		orderID, order.UserID, order.TotalPrice.Amount, order.TotalPrice.Currency, order.Status, createdAt)
 	if err != nil {
 		return "", err
 	}
 
 	for i := range items {
-		var productExists bool
-		err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM products WHERE id = $1)`, items[i].ProductID).Scan(&productExists)
-		if err != nil {
-			return "", err
-		}
-		if !productExists {
-			return "", errors.New("product does not exist")
-		}
-
 		itemID := uuid.New().String()
 		_, err = tx.Exec(ctx, `
-			INSERT INTO order_items (id, order_id, product_id, quantity, price)
-			VALUES ($1, $2, $3, $4, $5)`,
-			itemID, orderID, items[i].ProductID, items[i].Quantity, items[i].Price)
+			INSERT INTO order_items (id, order_id, product_id, product_name, quantity, price_amount, currency)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			itemID, orderID, items[i].ProductID, items[i].ProductName, items[i].Quantity, items[i].Price.Amount, items[i].Price.Currency)
 		if err != nil {
 			return "", err
 		}
@@ -73,6 +61,34 @@ func (r *OrderPostgresRepo) Save(order domain.Order, items []domain.OrderItem) (
 		items[i].OrderID = orderID
 	}
 
+	err = insertStatusChange(ctx, tx, domain.OrderStatusChange{
+		OrderID:   orderID,
+		NewStatus: order.Status,
+		ActorID:   order.UserID,
+		Reason:    "order created",
+		ChangedAt: createdAt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if order.IdempotencyKey != "" {
+		if err = completeIdempotencyKey(ctx, tx, order.UserID, order.IdempotencyKey, orderID); err != nil {
+			return "", err
+		}
+	}
+
+	err = insertOrderEvent(ctx, tx, domain.EventOrderCreated, domain.OrderEventPayload{
+		OrderID:    orderID,
+		UserID:     order.UserID,
+		Status:     order.Status,
+		Total:      order.TotalPrice,
+		TotalPrice: order.TotalPrice.Float64(),
+	})
+	if err != nil {
+		return "", err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return "", err
 	}
@@ -85,116 +101,283 @@ func (r *OrderPostgresRepo) FindByID(id string) (domain.Order, []domain.OrderIte
 	var order domain.Order
 
 	err := r.db.QueryRow(ctx, `
-		SELECT id, user_id, total_price, status, created_at
+		SELECT id, user_id, total_amount, currency, status, created_at, stock_released_at
 		FROM orders
 		WHERE id = $1`, id).
-		Scan(&order.ID, &order.UserID, &order.TotalPrice, &order.Status, &order.CreatedAt)
+		Scan(&order.ID, &order.UserID, &order.TotalPrice.Amount, &order.TotalPrice.Currency, &order.Status, &order.CreatedAt, &order.StockReleasedAt)
 	if err == pgx.ErrNoRows {
-		return domain.Order{}, nil, errors.New("order not found")
+		return domain.Order{}, nil, domain.ErrOrderNotFound
 	}
 	if err != nil {
 		return domain.Order{}, nil, err
 	}
 
 	rows, err := r.db.Query(ctx, `
-		SELECT id, order_id, product_id, quantity, price
+		SELECT `+orderItemColumns+`
 		FROM order_items
 		WHERE order_id = $1`, id)
 	if err != nil {
 		return domain.Order{}, nil, err
 	}
+	items, err := scanOrderItems(rows)
+	if err != nil {
+		return domain.Order{}, nil, err
+	}
+
+	return order, items, nil
+}
+
+const orderItemColumns = `id, order_id, product_id, product_name, quantity, price_amount, currency`
+
+// scanOrderItems reads and closes rows selecting orderItemColumns.
+func scanOrderItems(rows pgx.Rows) ([]domain.OrderItem, error) {
 	defer rows.Close()
 
 	var items []domain.OrderItem
 	for rows.Next() {
 		var item domain.OrderItem
-		if err := rows.Scan(&item.ID, &item.OrderID, &item.ProductID, &item.Quantity, &item.Price); err != nil {
-			return domain.Order{}, nil, err
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.ProductID, &item.ProductName, &item.Quantity, &item.Price.Amount, &item.Price.Currency); err != nil {
+			return nil, err
 		}
 		items = append(items, item)
 	}
+	return items, rows.Err()
+}
 
-	if err = rows.Err(); err != nil {
-		return domain.Order{}, nil, err
+// FindItemsByOrderIDs loads the items of several orders in one query, keyed
+// by order ID.
+func (r *OrderPostgresRepo) FindItemsByOrderIDs(orderIDs []string) (map[string][]domain.OrderItem, error) {
+	byOrder := make(map[string][]domain.OrderItem, len(orderIDs))
+	if len(orderIDs) == 0 {
+		return byOrder, nil
 	}
 
-	return order, items, nil
+	rows, err := r.db.Query(context.Background(), `
+		SELECT `+orderItemColumns+`
+		FROM order_items
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, id`, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	items, err := scanOrderItems(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		byOrder[item.OrderID] = append(byOrder[item.OrderID], item)
+	}
+	return byOrder, nil
 }
 
-func (r *OrderPostgresRepo) UpdateStatus(id string, status string) error {
+func (r *OrderPostgresRepo) UpdateStatus(change domain.OrderStatusChange) error {
 	ctx := context.Background()
-	result, err := r.db.Exec(ctx, `
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var payload domain.OrderEventPayload
+	err = tx.QueryRow(ctx, `
+		SELECT user_id, total_amount, currency, status
+		FROM orders
+		WHERE id = $1
+		FOR UPDATE`, change.OrderID).
+		Scan(&payload.UserID, &payload.Total.Amount, &payload.Total.Currency, &payload.PreviousStatus)
+	if err == pgx.ErrNoRows {
+		return domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if payload.PreviousStatus != change.OldStatus {
+		return domain.ErrOrderStatusChanged
+	}
+
+	_, err = tx.Exec(ctx, `
 		UPDATE orders
 		SET status = $1
-		WHERE id = $2`, status, id)
+		WHERE id = $2`, change.NewStatus, change.OrderID)
 	if err != nil {
 		return err
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		return errors.New("order not found")
+	if err := insertStatusChange(ctx, tx, change); err != nil {
+		return err
 	}
 
-	return nil
+	payload.OrderID = change.OrderID
+	payload.TotalPrice = payload.Total.Float64()
+	payload.Status = change.NewStatus
+	payload.ActorID = change.ActorID
+	payload.Reason = change.Reason
+	if err := insertOrderEvent(ctx, tx, domain.EventOrderStatusChanged, payload); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
-func (r *OrderPostgresRepo) FindByUserID(userID string) ([]domain.Order, error) {
+func insertStatusChange(ctx context.Context, tx pgx.Tx, change domain.OrderStatusChange) error {
+	changedAt := change.ChangedAt
+	if changedAt.IsZero() {
+		changedAt = time.Now()
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO order_status_history (order_id, old_status, new_status, actor_id, reason, changed_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6)`,
+		change.OrderID, change.OldStatus, change.NewStatus, change.ActorID, change.Reason, changedAt)
+	return err
+}
+
+func (r *OrderPostgresRepo) FindStatusHistory(orderID string) ([]domain.OrderStatusChange, error) {
 	ctx := context.Background()
 	rows, err := r.db.Query(ctx, `
-		SELECT id, user_id, total_price, status, created_at
-		FROM orders
-		WHERE user_id = $1`, userID)
+		SELECT id, order_id, old_status, new_status, COALESCE(actor_id, ''), COALESCE(reason, ''), changed_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY changed_at, id`, orderID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var orders []domain.Order
+	var history []domain.OrderStatusChange
 	for rows.Next() {
-		var order domain.Order
-		if err := rows.Scan(&order.ID, &order.UserID, &order.TotalPrice, &order.Status, &order.CreatedAt); err != nil {
+		var change domain.OrderStatusChange
+		if err := rows.Scan(&change.ID, &change.OrderID, &change.OldStatus, &change.NewStatus,
+			&change.ActorID, &change.Reason, &change.ChangedAt); err != nil {
 			return nil, err
 		}
-		orders = append(orders, order)
+		history = append(history, change)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return orders, nil
+	return history, nil
 }
 
-func (r *OrderPostgresRepo) FindAll() ([]domain.Order, error) {
+func (r *OrderPostgresRepo) MarkStockReleased(id string) error {
 	ctx := context.Background()
+	result, err := r.db.Exec(ctx, `
+		UPDATE orders
+		SET stock_released_at = COALESCE(stock_released_at, $1)
+		WHERE id = $2`, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrOrderNotFound
+	}
+	return nil
+}
+
+// orderSortColumns maps order sort keys to the columns they order by.
+var orderSortColumns = map[string]string{
+	domain.SortByCreatedAt: "created_at",
+	domain.SortByTotal:     "total_amount",
+}
+
+func orderFilterConditions(filter domain.OrderFilter, argCount int) (string, []interface{}, int) {
+	where := ""
+	args := []interface{}{}
+
+	if filter.UserID != "" {
+		where += fmt.Sprintf(" AND user_id = $%d", argCount)
+		args = append(args, filter.UserID)
+		argCount++
+	}
+	if len(filter.Statuses) > 0 {
+		where += fmt.Sprintf(" AND status = ANY($%d)", argCount)
+		args = append(args, filter.Statuses)
+		argCount++
+	}
+	if !filter.CreatedFrom.IsZero() {
+		where += fmt.Sprintf(" AND created_at >= $%d", argCount)
+		args = append(args, filter.CreatedFrom)
+		argCount++
+	}
+	if !filter.CreatedTo.IsZero() {
+		where += fmt.Sprintf(" AND created_at < $%d", argCount)
+		args = append(args, filter.CreatedTo)
+		argCount++
+	}
+	if filter.MinTotal.Amount > 0 {
+		where += fmt.Sprintf(" AND total_amount >= $%d AND currency = $%d", argCount, argCount+1)
+		args = append(args, filter.MinTotal.Amount, filter.MinTotal.Currency)
+		argCount += 2
+	}
+	if filter.MaxTotal.Amount > 0 {
+		where += fmt.Sprintf(" AND total_amount <= $%d AND currency = $%d", argCount, argCount+1)
+		args = append(args, filter.MaxTotal.Amount, filter.MaxTotal.Currency)
+		argCount += 2
+	}
+
+	return where, args, argCount
+}
+
+// ListOrders reads one page of orders without their items, ordered by sort
+// and then by id. Matching orders are only counted when the page is read by
+// offset.
+func (r *OrderPostgresRepo) ListOrders(filter domain.OrderFilter, sort domain.SortParams, pagination domain.PaginationParams, offset int, cursor *domain.Cursor) (domain.OrderPage, error) {
+	ctx := context.Background()
+	where, args, argCount := orderFilterConditions(filter, 1)
+
+	var page domain.OrderPage
+	if cursor == nil {
+		countQuery := `SELECT COUNT(*) FROM orders WHERE 1=1` + where
+		if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&page.Total); err != nil {
+			return page, err
+		}
+	}
+
+	keys := newKeyset(orderSortColumns[sort.By], sort, cursor)
+	if cursor != nil {
+		value, err := domain.OrderSortValue(*cursor)
+		if err != nil {
+			return page, domain.ErrInvalidCursor
+		}
+		cond, condArgs, next := keys.condition(value, argCount)
+		where += cond
+		args = append(args, condArgs...)
+		argCount = next
+	}
+	limit, limitArgs := keys.limit(pagination.PerPage, offset, argCount)
+	args = append(args, limitArgs...)
+
 	rows, err := r.db.Query(ctx, `
-		SELECT id, user_id, total_price, status, created_at
-		FROM orders`)
+		SELECT id, user_id, total_amount, currency, status, created_at
+		FROM orders
+		WHERE 1=1`+where+limit, args...)
 	if err != nil {
-		return nil, err
+		return page, err
 	}
 	defer rows.Close()
 
 	var orders []domain.Order
 	for rows.Next() {
 		var order domain.Order
-		if err := rows.Scan(&order.ID, &order.UserID, &order.TotalPrice, &order.Status, &order.CreatedAt); err != nil {
-			return nil, err
+		if err := rows.Scan(&order.ID, &order.UserID, &order.TotalPrice.Amount, &order.TotalPrice.Currency, &order.Status, &order.CreatedAt); err != nil {
+			return page, err
 		}
 		orders = append(orders, order)
 	}
-
-	if err = rows.Err(); err != nil {
-		return nil, err
+	if err := rows.Err(); err != nil {
+		return page, err
 	}
 
-	return orders, nil
+	var hasNext, hasPrev bool
+	page.Orders, hasNext, hasPrev = keysetPage(keys, orders, pagination.PerPage, offset)
+	if len(page.Orders) > 0 {
+		if hasNext {
+			page.NextCursor = domain.NewOrderCursor(page.Orders[len(page.Orders)-1], sort, false).Encode()
+		}
+		if hasPrev {
+			page.PrevCursor = domain.NewOrderCursor(page.Orders[0], sort, true).Encode()
+		}
+	}
+	return page, nil
 }
-func (r *OrderPostgresRepo) DeleteOrderItemsByProduct(productID string) error {
-    ctx := context.Background()
-    _, err := r.db.Exec(ctx, `
-        DELETE FROM order_items
-        WHERE product_id = $1`, productID)
-    return err
-}
\ No newline at end of file
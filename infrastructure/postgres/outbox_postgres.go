@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type OutboxPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxPostgresRepo() *OutboxPostgresRepo {
+	return &OutboxPostgresRepo{db: DB}
+}
+
+// insertOrderEvent writes an event row inside the caller's transaction so the
+// event is recorded if and only if the order change commits.
+func insertOrderEvent(ctx context.Context, tx pgx.Tx, eventType string, payload domain.OrderEventPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO outbox (aggregate_id, event_type, payload, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $4)`,
+		payload.OrderID, eventType, data, time.Now())
+	return err
+}
+
+// ClaimPending leases up to limit undelivered events. A claimed event becomes
+// visible again once the lease expires, so a crashed relay never loses events.
+func (r *OutboxPostgresRepo) ClaimPending(limit int, lease time.Duration) ([]domain.OrderEvent, error) {
+	ctx := context.Background()
+	now := time.Now()
+	rows, err := r.db.Query(ctx, `
+		UPDATE outbox
+		SET next_attempt_at = $2, attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE delivered_at IS NULL AND next_attempt_at <= $3
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+		RETURNING id, aggregate_id, event_type, payload, created_at, attempts`,
+		limit, now.Add(lease), now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.OrderEvent
+	for rows.Next() {
+		var event domain.OrderEvent
+		if err := rows.Scan(&event.ID, &event.OrderID, &event.Type, &event.Payload, &event.CreatedAt, &event.Attempts); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	return events, nil
+}
+
+func (r *OutboxPostgresRepo) MarkDelivered(id int64) error {
+	ctx := context.Background()
+	_, err := r.db.Exec(ctx, `
+		UPDATE outbox
+		SET delivered_at = $1, last_error = NULL
+		WHERE id = $2`, time.Now(), id)
+	return err
+}
+
+func (r *OutboxPostgresRepo) MarkFailed(id int64, retryAt time.Time, reason string) error {
+	ctx := context.Background()
+	_, err := r.db.Exec(ctx, `
+		UPDATE outbox
+		SET next_attempt_at = $1, last_error = $2
+		WHERE id = $3`, retryAt, reason, id)
+	return err
+}
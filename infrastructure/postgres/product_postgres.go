@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"FoodStore-AdvProg2/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 )
 
 type ProductPostgresRepo struct{}
@@ -12,95 +16,488 @@ func NewProductPostgresRepo() *ProductPostgresRepo {
 	return &ProductPostgresRepo{}
 }
 
-func (r *ProductPostgresRepo) Save(product domain.Product) error {
-	query := `INSERT INTO products (id, name, price, stock) VALUES ($1, $2, $3, $4)`
-	_, err := DB.Exec(context.Background(), query,
-		product.ID, product.Name, product.Price, product.Stock)
+// productColumns selects a product row along with its tags and allergens, in
+// the order scanProduct reads them.
+const productColumns = `id, name, price_amount, currency, stock, created_at, archived_at,
+	description, image_url, unit, COALESCE(category_id::text, ''),
+	ARRAY(SELECT tag FROM product_tags t WHERE t.product_id = products.id ORDER BY tag),
+	ARRAY(SELECT allergen FROM product_allergens a WHERE a.product_id = products.id ORDER BY allergen)`
+
+// scanProduct reads the productColumns of a row followed by any extra
+// columns into extra.
+func scanProduct(row pgx.Row, extra ...interface{}) (domain.Product, error) {
+	var p domain.Product
+	dest := []interface{}{&p.ID, &p.Name, &p.Price.Amount, &p.Price.Currency, &p.Stock, &p.CreatedAt, &p.ArchivedAt,
+		&p.Description, &p.ImageURL, &p.Unit, &p.CategoryID, &p.Tags, &p.Allergens}
+	err := row.Scan(append(dest, extra...)...)
+	return p, err
+}
+
+// saveLabels replaces a product's tags and allergens.
+func saveLabels(ctx context.Context, tx pgx.Tx, product domain.Product) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM product_tags WHERE product_id = $1`, product.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM product_allergens WHERE product_id = $1`, product.ID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO product_tags (product_id, tag)
+		SELECT $1, UNNEST($2::text[])`, product.ID, product.Tags)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO product_allergens (product_id, allergen)
+		SELECT $1, UNNEST($2::text[])`, product.ID, product.Allergens)
 	return err
 }
 
-func (r *ProductPostgresRepo) FindByID(id string) (domain.Product, error) {
-	query := `SELECT id, name, price, stock FROM products WHERE id = $1`
-	row := DB.QueryRow(context.Background(), query, id)
+func (r *ProductPostgresRepo) Save(product domain.Product) error {
+	ctx := context.Background()
+	tx, err := DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
 
-	var p domain.Product
-	err := row.Scan(&p.ID, &p.Name, &p.Price, &p.Stock)
+	_, err = tx.Exec(ctx, `
+		INSERT INTO products (id, name, price_amount, currency, stock, description, image_url, unit, category_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::uuid)`,
+		product.ID, product.Name, product.Price.Amount, product.Price.Currency, product.Stock,
+		product.Description, product.ImageURL, product.Unit, product.CategoryID)
+	if err != nil {
+		return err
+	}
+	if err := saveLabels(ctx, tx, product); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// FindByID also returns archived products.
+func (r *ProductPostgresRepo) FindByID(id string) (domain.Product, error) {
+	query := `SELECT ` + productColumns + ` FROM products WHERE id = $1`
+	p, err := scanProduct(DB.QueryRow(context.Background(), query, id))
+	if err == pgx.ErrNoRows {
+		return p, domain.ErrProductNotFound
+	}
 	return p, err
 }
 
+// FindByIDs reads the products with the given IDs, archived ones included.
+// IDs that match no product, or are not UUIDs, are left out.
+func (r *ProductPostgresRepo) FindByIDs(ids []string) ([]domain.Product, error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, nil
+	}
+
+	rows, err := DB.Query(context.Background(), `SELECT `+productColumns+` FROM products WHERE id = ANY($1)`, valid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []domain.Product
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+	return products, rows.Err()
+}
+
 func (r *ProductPostgresRepo) Update(id string, product domain.Product) error {
-	query := `UPDATE products SET name=$1, price=$2, stock=$3 WHERE id=$4`
-	_, err := DB.Exec(context.Background(), query, product.Name, product.Price, product.Stock, id)
-	return err
+	ctx := context.Background()
+	tx, err := DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE products
+		SET name = $1, price_amount = $2, currency = $3, stock = $4,
+			description = $5, image_url = $6, unit = $7, category_id = NULLIF($8, '')::uuid
+		WHERE id = $9`,
+		product.Name, product.Price.Amount, product.Price.Currency, product.Stock,
+		product.Description, product.ImageURL, product.Unit, product.CategoryID, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrProductNotFound
+	}
+
+	product.ID = id
+	if err := saveLabels(ctx, tx, product); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
-func (r *ProductPostgresRepo) Delete(id string) error {
-	query := `DELETE FROM products WHERE id=$1`
-	_, err := DB.Exec(context.Background(), query, id)
-	return err
+func (r *ProductPostgresRepo) Archive(id string, at time.Time) error {
+	query := `UPDATE products SET archived_at = COALESCE(archived_at, $1) WHERE id = $2`
+	result, err := DB.Exec(context.Background(), query, at, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrProductNotFound
+	}
+	return nil
 }
 
-func (r *ProductPostgresRepo) FindAllWithFilter(filter domain.FilterParams, pagination domain.PaginationParams, offset int) ([]domain.Product, int, error) {
-	query := `SELECT id, name, price, stock FROM products WHERE 1=1`
-	countQuery := `SELECT COUNT(*) FROM products WHERE 1=1`
+func (r *ProductPostgresRepo) Restore(id string) error {
+	query := `UPDATE products SET archived_at = NULL WHERE id = $1`
+	result, err := DB.Exec(context.Background(), query, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrProductNotFound
+	}
+	return nil
+}
+
+// filterConditions turns a filter into AND conditions for a products query,
+// numbering placeholders from argCount. It returns the conditions, their
+// arguments and the next free placeholder number.
+func filterConditions(filter domain.FilterParams, argCount int) (string, []interface{}, int) {
+	where := ""
 	args := []interface{}{}
-	argCount := 1
+
+	if !filter.IncludeArchived {
+		where += " AND archived_at IS NULL"
+	}
 
 	if filter.Name != "" {
-		query += fmt.Sprintf(" AND name ILIKE $%d", argCount)
-		countQuery += fmt.Sprintf(" AND name ILIKE $%d", argCount)
+		where += fmt.Sprintf(" AND name ILIKE $%d", argCount)
 		args = append(args, "%"+filter.Name+"%")
 		argCount++
 	}
-	if filter.MinPrice > 0 {
-		query += fmt.Sprintf(" AND price >= $%d", argCount)
-		countQuery += fmt.Sprintf(" AND price >= $%d", argCount)
-		args = append(args, filter.MinPrice)
+	if filter.CategoryID != "" {
+		subtree := fmt.Sprintf(` AND category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM categories WHERE id = $%d
+				UNION ALL
+				SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+			)
+			SELECT id FROM subtree)`, argCount)
+		where += subtree
+		args = append(args, filter.CategoryID)
 		argCount++
 	}
-	if filter.MaxPrice > 0 {
-		query += fmt.Sprintf(" AND price <= $%d", argCount)
-		countQuery += fmt.Sprintf(" AND price <= $%d", argCount)
-		args = append(args, filter.MaxPrice)
+	if len(filter.Tags) > 0 {
+		tags := fmt.Sprintf(` AND id IN (
+			SELECT product_id FROM product_tags
+			WHERE tag = ANY($%d)
+			GROUP BY product_id
+			HAVING COUNT(*) = $%d)`, argCount, argCount+1)
+		where += tags
+		args = append(args, filter.Tags, len(filter.Tags))
+		argCount += 2
+	}
+	if len(filter.ExcludeAllergens) > 0 {
+		allergens := fmt.Sprintf(` AND NOT EXISTS (
+			SELECT 1 FROM product_allergens a
+			WHERE a.product_id = products.id AND a.allergen = ANY($%d))`, argCount)
+		where += allergens
+		args = append(args, filter.ExcludeAllergens)
 		argCount++
 	}
+	if filter.Unit != "" {
+		where += fmt.Sprintf(" AND unit = $%d", argCount)
+		args = append(args, filter.Unit)
+		argCount++
+	}
+	// A price bound only matches products priced in the bound's currency.
+	if filter.MinPrice.Amount > 0 {
+		where += fmt.Sprintf(" AND price_amount >= $%d AND currency = $%d", argCount, argCount+1)
+		args = append(args, filter.MinPrice.Amount, filter.MinPrice.Currency)
+		argCount += 2
+	}
+	if filter.MaxPrice.Amount > 0 {
+		where += fmt.Sprintf(" AND price_amount <= $%d AND currency = $%d", argCount, argCount+1)
+		args = append(args, filter.MaxPrice.Amount, filter.MaxPrice.Currency)
+		argCount += 2
+	}
 
-	query += fmt.Sprintf(" ORDER BY id LIMIT $%d OFFSET $%d", argCount, argCount+1)
-	args = append(args, pagination.PerPage, offset)
+	return where, args, argCount
+}
 
-	var total int
-	if len(args) > 2 {
-		err := DB.QueryRow(context.Background(), countQuery, args[:len(args)-2]...).Scan(&total)
-		if err != nil {
-			return nil, 0, err
+// sortColumns maps sort keys to the product columns they order by.
+var sortColumns = map[string]string{
+	domain.SortByPrice:     "price_amount",
+	domain.SortByName:      "name",
+	domain.SortByStock:     "stock",
+	domain.SortByCreatedAt: "created_at",
+}
+
+// FindAllWithFilter reads one page of products ordered by sort and then by
+// id. Matching products are only counted when the page is read by offset.
+func (r *ProductPostgresRepo) FindAllWithFilter(filter domain.FilterParams, sort domain.SortParams, pagination domain.PaginationParams, offset int, cursor *domain.Cursor) (domain.ProductPage, error) {
+	ctx := context.Background()
+	where, args, argCount := filterConditions(filter, 1)
+
+	var page domain.ProductPage
+	if cursor == nil {
+		countQuery := `SELECT COUNT(*) FROM products WHERE 1=1` + where
+		if err := DB.QueryRow(ctx, countQuery, args...).Scan(&page.Total); err != nil {
+			return page, err
 		}
-	} else {
-		err := DB.QueryRow(context.Background(), countQuery).Scan(&total)
+	}
+
+	keys := newKeyset(sortColumns[sort.By], sort, cursor)
+	if cursor != nil {
+		value, err := domain.ProductSortValue(*cursor)
 		if err != nil {
-			return nil, 0, err
+			return page, domain.ErrInvalidCursor
 		}
+		cond, condArgs, next := keys.condition(value, argCount)
+		where += cond
+		args = append(args, condArgs...)
+		argCount = next
 	}
+	limit, limitArgs := keys.limit(pagination.PerPage, offset, argCount)
+	query := `SELECT ` + productColumns + ` FROM products WHERE 1=1` + where + limit
+	args = append(args, limitArgs...)
 
-	rows, err := DB.Query(context.Background(), query, args...)
+	rows, err := DB.Query(ctx, query, args...)
 	if err != nil {
-		return nil, 0, err
+		return page, err
 	}
 	defer rows.Close()
 
 	var products []domain.Product
 	for rows.Next() {
-		var p domain.Product
-		err := rows.Scan(&p.ID, &p.Name, &p.Price, &p.Stock)
+		p, err := scanProduct(rows)
 		if err != nil {
-			return nil, 0, err
+			return page, err
 		}
 		products = append(products, p)
 	}
+	if err := rows.Err(); err != nil {
+		return page, err
+	}
 
-	return products, total, nil
+	var hasNext, hasPrev bool
+	page.Products, hasNext, hasPrev = keysetPage(keys, products, pagination.PerPage, offset)
+	if len(page.Products) > 0 {
+		if hasNext {
+			page.NextCursor = domain.NewProductCursor(page.Products[len(page.Products)-1], sort, false).Encode()
+		}
+		if hasPrev {
+			page.PrevCursor = domain.NewProductCursor(page.Products[0], sort, true).Encode()
+		}
+	}
+	return page, nil
 }
 
 func (r *ProductPostgresRepo) FindAll() ([]domain.Product, error) {
-	products, _, err := r.FindAllWithFilter(domain.FilterParams{}, domain.PaginationParams{PerPage: 1000}, 0)
-	return products, err
-}
\ No newline at end of file
+	page, err := r.FindAllWithFilter(domain.FilterParams{}, domain.SortParams{}, domain.PaginationParams{PerPage: 1000}, 0, nil)
+	return page.Products, err
+}
+
+// ts_headline options: matched words are wrapped in <mark> tags and
+// descriptions are cut down to short fragments around the matches.
+const (
+	nameHighlightOptions    = `StartSel=<mark>, StopSel=</mark>, HighlightAll=true`
+	snippetHighlightOptions = `StartSel=<mark>, StopSel=</mark>, MaxWords=25, MinWords=8, MaxFragments=2, FragmentDelimiter=" ... "`
+)
+
+// escapeHTML wraps a text column in SQL that escapes it for HTML, so that
+// the <mark> tags ts_headline adds are the only markup in its output.
+func escapeHTML(column string) string {
+	return `replace(replace(replace(replace(replace(` + column +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
+
+// Search matches text against the name, tags and description through the
+// search_vector index, and against the name by trigram word similarity so
+// misspelt words still match. Results are ordered by the sum of both scores.
+func (r *ProductPostgresRepo) Search(text string, filter domain.FilterParams, pagination domain.PaginationParams, offset int) ([]domain.SearchResult, int, error) {
+	ctx := context.Background()
+	where, args, argCount := filterConditions(filter, 2)
+	args = append([]interface{}{text}, args...)
+
+	from := ` FROM products, websearch_to_tsquery('english', $1) AS tsq
+		WHERE (search_vector @@ tsq OR $1 <% name)` + where
+
+	var total int
+	if err := DB.QueryRow(ctx, `SELECT COUNT(*)`+from, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + productColumns + `,
+			ts_rank_cd(search_vector, tsq) + word_similarity($1, name) AS rank,
+			ts_headline('english', ` + escapeHTML("name") + `, tsq, '` + nameHighlightOptions + `'),
+			ts_headline('english', ` + escapeHTML("description") + `, tsq, '` + snippetHighlightOptions + `')` +
+		from +
+		fmt.Sprintf(" ORDER BY rank DESC, id LIMIT $%d OFFSET $%d", argCount, argCount+1)
+	args = append(args, pagination.PerPage, offset)
+
+	rows, err := DB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []domain.SearchResult
+	for rows.Next() {
+		var res domain.SearchResult
+		var err error
+		res.Product, err = scanProduct(rows, &res.Rank, &res.NameHighlight, &res.Snippet)
+		if err != nil {
+			return nil, 0, err
+		}
+		results = append(results, res)
+	}
+	return results, total, rows.Err()
+}
+
+// FindTags returns every tag used by a listed product with the number of
+// listed products carrying it, sorted by tag.
+func (r *ProductPostgresRepo) FindTags() ([]domain.TagCount, error) {
+	rows, err := DB.Query(context.Background(), `
+		SELECT t.tag, COUNT(*)
+		FROM product_tags t
+		JOIN products p ON p.id = t.product_id
+		WHERE p.archived_at IS NULL
+		GROUP BY t.tag
+		ORDER BY t.tag`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []domain.TagCount
+	for rows.Next() {
+		var tag domain.TagCount
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+// AdjustStock adds delta, which may be negative, to a product's stock in one
+// conditional update, so it cannot overwrite a concurrent reservation.
+func (r *ProductPostgresRepo) AdjustStock(id string, delta int) error {
+	ctx := context.Background()
+	result, err := DB.Exec(ctx, `
+		UPDATE products
+		SET stock = stock + $2
+		WHERE id = $1 AND stock + $2 >= 0`, id, delta)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := DB.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrProductNotFound
+	}
+	return fmt.Errorf("%w for product %s", domain.ErrInsufficientStock, id)
+}
+
+func (r *ProductPostgresRepo) ReserveStock(reservation domain.StockReservation) error {
+	ctx := context.Background()
+	tx, err := DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		INSERT INTO stock_reservations (reference, status, created_at)
+		VALUES ($1, 'reserved', $2)
+		ON CONFLICT (reference) DO NOTHING`, reservation.Reference, time.Now())
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return nil
+	}
+
+	for _, line := range reservation.Lines {
+		result, err := tx.Exec(ctx, `
+			UPDATE products
+			SET stock = stock - $1
+			WHERE id = $2 AND stock >= $1 AND archived_at IS NULL`, line.Quantity, line.ProductID)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			var archivedAt *time.Time
+			err = tx.QueryRow(ctx, `SELECT archived_at FROM products WHERE id = $1`, line.ProductID).Scan(&archivedAt)
+			if err == pgx.ErrNoRows {
+				return fmt.Errorf("%w: %s", domain.ErrProductNotFound, line.ProductID)
+			}
+			if err != nil {
+				return err
+			}
+			if archivedAt != nil {
+				return fmt.Errorf("%w: %s", domain.ErrProductArchived, line.ProductID)
+			}
+			return fmt.Errorf("%w for product %s", domain.ErrInsufficientStock, line.ProductID)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO stock_reservation_lines (reference, product_id, quantity)
+			VALUES ($1, $2, $3)`, reservation.Reference, line.ProductID, line.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ProductPostgresRepo) ReleaseStock(reference string) (bool, error) {
+	ctx := context.Background()
+	tx, err := DB.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE stock_reservations
+		SET status = 'released', released_at = $2
+		WHERE reference = $1 AND status = 'reserved'`, reference, time.Now())
+	if err != nil {
+		return false, err
+	}
+	if result.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE products p
+		SET stock = p.stock + l.quantity
+		FROM stock_reservation_lines l
+		WHERE l.reference = $1 AND p.id = l.product_id`, reference)
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type SagaPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSagaPostgresRepo() *SagaPostgresRepo {
+	return &SagaPostgresRepo{db: DB}
+}
+
+func (r *SagaPostgresRepo) Create(saga domain.Saga) error {
+	ctx := context.Background()
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO order_sagas (id, saga_type, order_id, status, completed_steps, payload, last_error, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		saga.ID, saga.Type, saga.OrderID, saga.Status, saga.CompletedSteps, saga.Payload, saga.LastError, saga.CreatedAt, saga.UpdatedAt)
+	return err
+}
+
+func (r *SagaPostgresRepo) Update(saga domain.Saga) error {
+	ctx := context.Background()
+	result, err := r.db.Exec(ctx, `
+		UPDATE order_sagas
+		SET status = $1, completed_steps = $2, last_error = $3, updated_at = $4
+		WHERE id = $5`,
+		saga.Status, saga.CompletedSteps, saga.LastError, time.Now(), saga.ID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("saga not found")
+	}
+	return nil
+}
+
+func (r *SagaPostgresRepo) FindUnfinished(sagaType string, updatedBefore time.Time) ([]domain.Saga, error) {
+	ctx := context.Background()
+	rows, err := r.db.Query(ctx, `
+		SELECT id, saga_type, order_id, status, completed_steps, payload, COALESCE(last_error, ''), created_at, updated_at
+		FROM order_sagas
+		WHERE saga_type = $1 AND status IN ($2, $3) AND updated_at < $4
+		ORDER BY created_at`, sagaType, domain.SagaStatusRunning, domain.SagaStatusCompensating, updatedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sagas []domain.Saga
+	for rows.Next() {
+		var saga domain.Saga
+		if err := rows.Scan(&saga.ID, &saga.Type, &saga.OrderID, &saga.Status, &saga.CompletedSteps,
+			&saga.Payload, &saga.LastError, &saga.CreatedAt, &saga.UpdatedAt); err != nil {
+			return nil, err
+		}
+		sagas = append(sagas, saga)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sagas, nil
+}
@@ -24,10 +24,15 @@ func (r *UserPostgresRepo) Save(user domain.User) (string, error) {
     userID := uuid.New().String()
     createdAt := time.Now()
 
+    role := user.Role
+    if role == "" {
+        role = domain.RoleCustomer
+    }
+
     _, err := r.db.Exec(ctx, `
-        INSERT INTO users (id, username, email, password, created_at)
-        VALUES ($1, $2, $3, $4, $5)`,
-        userID, user.Username, user.Email, user.Password, createdAt)
+        INSERT INTO users (id, username, email, password, role, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)`,
+        userID, user.Username, user.Email, user.Password, role, createdAt)
     if err != nil {
         return "", err
     }
@@ -40,12 +45,12 @@ func (r *UserPostgresRepo) FindByUsername(username string) (domain.User, error)
     var user domain.User
 
     err := r.db.QueryRow(ctx, `
-        SELECT id, username, email, password, created_at
+        SELECT id, username, email, password, role, created_at
         FROM users
         WHERE username = $1`, username).
-        Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.CreatedAt)
+        Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role, &user.CreatedAt)
     if err == pgx.ErrNoRows {
-        return domain.User{}, errors.New("user not found")
+        return domain.User{}, domain.ErrUserNotFound
     }
     if err != nil {
         return domain.User{}, err
@@ -59,12 +64,12 @@ func (r *UserPostgresRepo) FindByID(id string) (domain.User, error) {
     var user domain.User
 
     err := r.db.QueryRow(ctx, `
-        SELECT id, username, email, password, created_at
+        SELECT id, username, email, password, role, created_at
         FROM users
         WHERE id = $1`, id).
-        Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.CreatedAt)
+        Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role, &user.CreatedAt)
     if err == pgx.ErrNoRows {
-        return domain.User{}, errors.New("user not found")
+        return domain.User{}, domain.ErrUserNotFound
     }
     if err != nil {
         return domain.User{}, err
@@ -76,27 +81,81 @@ func (r *UserPostgresRepo) FindByID(id string) (domain.User, error) {
 func (r *UserPostgresRepo) SaveToken(token domain.Token) error {
     ctx := context.Background()
     _, err := r.db.Exec(ctx, `
-        INSERT INTO tokens (user_id, token, created_at)
-        VALUES ($1, $2, $3)`,
-        token.UserID, token.Token, token.CreatedAt)
+        INSERT INTO tokens (user_id, token, created_at, expires_at)
+        VALUES ($1, $2, $3, $4)`,
+        token.UserID, token.Token, token.CreatedAt, token.ExpiresAt)
     return err
 }
 
-func (r *UserPostgresRepo) FindUserIDByToken(token string) (string, error) {
+func (r *UserPostgresRepo) FindUserByToken(token string) (domain.User, domain.Token, error) {
     ctx := context.Background()
-    var userID string
+    var user domain.User
+    var t domain.Token
 
     err := r.db.QueryRow(ctx, `
-        SELECT user_id
-        FROM tokens
-        WHERE token = $1`, token).
-        Scan(&userID)
+        SELECT u.id, u.username, u.email, u.role, u.created_at, t.token, t.created_at, t.expires_at
+        FROM tokens t
+        JOIN users u ON u.id = t.user_id
+        WHERE t.token = $1`, token).
+        Scan(&user.ID, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &t.Token, &t.CreatedAt, &t.ExpiresAt)
     if err == pgx.ErrNoRows {
-        return "", errors.New("token not found")
+        return domain.User{}, domain.Token{}, errors.New("token not found")
     }
     if err != nil {
-        return "", err
+        return domain.User{}, domain.Token{}, err
     }
 
-    return userID, nil
+    t.UserID = user.ID
+    return user, t, nil
+}
+
+func (r *UserPostgresRepo) UpdateRole(userID, role string) error {
+    ctx := context.Background()
+    result, err := r.db.Exec(ctx, `
+        UPDATE users
+        SET role = $1
+        WHERE id = $2`, role, userID)
+    if err != nil {
+        return err
+    }
+    if result.RowsAffected() == 0 {
+        return domain.ErrUserNotFound
+    }
+    return nil
+}
+
+func (r *UserPostgresRepo) DeleteToken(token string) (bool, error) {
+    ctx := context.Background()
+    result, err := r.db.Exec(ctx, `DELETE FROM tokens WHERE token = $1`, token)
+    if err != nil {
+        return false, err
+    }
+    return result.RowsAffected() > 0, nil
+}
+
+func (r *UserPostgresRepo) DeleteUserToken(userID, token string) (bool, error) {
+    ctx := context.Background()
+    result, err := r.db.Exec(ctx, `DELETE FROM tokens WHERE token = $1 AND user_id = $2`, token, userID)
+    if err != nil {
+        return false, err
+    }
+    return result.RowsAffected() > 0, nil
+}
+
+func (r *UserPostgresRepo) DeleteTokensByUserID(userID string) (int64, error) {
+    ctx := context.Background()
+    result, err := r.db.Exec(ctx, `DELETE FROM tokens WHERE user_id = $1`, userID)
+    if err != nil {
+        return 0, err
+    }
+    return result.RowsAffected(), nil
+}
+
+func (r *UserPostgresRepo) DeleteExpiredTokens(now time.Time) (int64, error) {
+    ctx := context.Background()
+    result, err := r.db.Exec(ctx, `DELETE FROM tokens WHERE expires_at <= $1`, now)
+    if err != nil {
+        return 0, err
+    }
+    return result.RowsAffected(), nil
 }
\ No newline at end of file
@@ -10,7 +10,8 @@ const state = {
         maxPrice: ''
     },
     userId: '',
-    orders: []
+    orders: [],
+    checkoutKey: ''
 };
 
 const DOM = {
@@ -44,11 +45,7 @@ document.addEventListener('DOMContentLoaded', () => {
     DOM.viewOrdersButton.addEventListener('click', fetchOrders);
     DOM.userIdInput.addEventListener('input', updateCheckoutButton);
 
-    const savedCart = localStorage.getItem('cart');
-    if (savedCart) {
-        state.cart = JSON.parse(savedCart);
-        updateCart();
-    }
+    fetchCart();
 
     const savedUserId = localStorage.getItem('userId');
     if (savedUserId) {
@@ -60,6 +57,60 @@ document.addEventListener('DOMContentLoaded', () => {
     }
 });
 
+// apiFetch sends a JSON request to the gateway as the signed-in user.
+function apiFetch(path, options = {}) {
+    const headers = { 'Content-Type': 'application/json', ...options.headers };
+    const token = localStorage.getItem('accessToken');
+    if (token) {
+        headers.Authorization = `Bearer ${token}`;
+    }
+    return fetch(path, { ...options, headers });
+}
+
+// setCart replaces the local copy of the cart with the one the server returned.
+function setCart(cart) {
+    state.cart = cart.items.map(item => ({
+        id: item.product_id,
+        name: item.name,
+        price: item.current_price,
+        stock: item.stock,
+        quantity: item.quantity,
+        problem: item.problem
+    }));
+    updateCart();
+}
+
+async function fetchCart() {
+    try {
+        const response = await apiFetch('/api/cart');
+        if (!response.ok) {
+            throw new Error(`Error fetching cart: ${response.statusText}`);
+        }
+        setCart(await response.json());
+    } catch (error) {
+        console.error('Error fetching cart:', error);
+    }
+}
+
+// changeCart sends a cart change and shows the cart it results in.
+async function changeCart(path, method, body) {
+    try {
+        const response = await apiFetch(path, {
+            method,
+            body: body ? JSON.stringify(body) : undefined
+        });
+        if (!response.ok) {
+            const data = await response.json().catch(() => ({}));
+            throw new Error(data.error || `Error updating cart: ${response.statusText}`);
+        }
+        setCart(await response.json());
+    } catch (error) {
+        console.error('Error updating cart:', error);
+        alert(error.message);
+        fetchCart();
+    }
+}
+
 async function fetchProducts() {
     try {
         const url = new URL('/api/products', window.location.origin);
@@ -169,33 +220,13 @@ function renderPagination() {
     }
 }
 
-function addToCart(event) {
+async function addToCart(event) {
     const button = event.target;
-    const id = button.dataset.id;
-    const name = button.dataset.name;
-    const price = parseFloat(button.dataset.price);
-    const stock = parseInt(button.dataset.stock);
-    
-    const existingItem = state.cart.find(item => item.id === id);
-    
-    if (existingItem) {
-        existingItem.quantity += 1;
-    } else {
-        state.cart.push({
-            id,
-            name,
-            price,
-            stock,
-            quantity: 1
-        });
-    }
     
     button.disabled = true;
     button.textContent = 'Cart';
     
-    localStorage.setItem('cart', JSON.stringify(state.cart));
-    
-    updateCart();
+    await changeCart('/api/cart/items', 'POST', { product_id: button.dataset.id, quantity: 1 });
 }
 
 function updateCart() {
@@ -255,36 +286,28 @@ function decreaseQuantity(id) {
     const item = state.cart.find(item => item.id === id);
     if (!item) return;
     
-    item.quantity -= 1;
-    
-    if (item.quantity <= 0) {
+    if (item.quantity <= 1) {
         removeFromCart(id);
-    } else {
-        localStorage.setItem('cart', JSON.stringify(state.cart));
-        updateCart();
+        return;
     }
+    changeCart(`/api/cart/items/${id}`, 'PUT', { quantity: item.quantity - 1 });
 }
 
 function increaseQuantity(id) {
     const item = state.cart.find(item => item.id === id);
     if (!item || item.quantity >= item.stock) return;
     
-    item.quantity += 1;
-    localStorage.setItem('cart', JSON.stringify(state.cart));
-    updateCart();
+    changeCart(`/api/cart/items/${id}`, 'PUT', { quantity: item.quantity + 1 });
 }
 
-function removeFromCart(id) {
-    state.cart = state.cart.filter(item => item.id !== id);
-    localStorage.setItem('cart', JSON.stringify(state.cart));
+async function removeFromCart(id) {
+    await changeCart(`/api/cart/items/${id}`, 'DELETE');
     
     const productButton = document.querySelector(`button[data-id="${id}"]`);
     if (productButton) {
         productButton.disabled = false;
         productButton.textContent = 'Add to cart';
     }
-    
-    updateCart();
 }
 
 function applyFilters() {
@@ -325,32 +348,27 @@ async function placeOrder(event) {
     const userId = DOM.userIdInput.value.trim();
     if (!userId || state.cart.length === 0) return;
     
+    // The same key is sent until the checkout succeeds, so a retry after a
+    // lost response returns the order that was already placed.
+    if (!state.checkoutKey) {
+        state.checkoutKey = crypto.randomUUID();
+    }
+    
     try {
-        const orderData = {
-            user_id: userId,
-            items: state.cart.map(item => ({
-                product_id: item.id,
-                quantity: item.quantity
-            }))
-        };
-        
-        const response = await fetch('/api/orders', {
+        const response = await apiFetch('/api/cart/checkout', {
             method: 'POST',
-            headers: {
-                'Content-Type': 'application/json'
-            },
-            body: JSON.stringify(orderData)
+            headers: { 'Idempotency-Key': state.checkoutKey }
         });
         
+        const result = await response.json().catch(() => ({}));
         if (!response.ok) {
-            const errorText = await response.text();
-            throw new Error(`Error creating order: ${errorText}`);
+            // The cart may have been updated to new prices; show it again.
+            fetchCart();
+            throw new Error(`Error creating order: ${result.error || response.statusText}`);
         }
         
-        const result = await response.json();
-        
+        state.checkoutKey = '';
         state.cart = [];
-        localStorage.setItem('cart', JSON.stringify(state.cart));
         updateCart();
         
         fetchProducts();
@@ -372,14 +390,15 @@ async function fetchOrders() {
     try {
         const url = new URL('/api/orders', window.location.origin);
         url.searchParams.append('user_id', userId);
+        url.searchParams.append('include_items', 'true');
         
-        const response = await fetch(url);
+        const response = await apiFetch(url.pathname + url.search);
         if (!response.ok) {
             throw new Error(`Error fetching orders: ${response.statusText}`);
         }
         
-        const orders = await response.json();
-        state.orders = orders;
+        const data = await response.json();
+        state.orders = data.orders;
         
         renderOrders();
     } catch (error) {
@@ -431,7 +450,7 @@ function renderOrderItems(items) {
     let html = '<div class="main__order-item-products">';
     
     items.forEach(item => {
-        const productName = item.product ? item.product.Name : 'Product not found';
+        const productName = item.product_name || 'Product not found';
         html += `
             <div class="main__order-item-product">
                 <div class="main__order-item-product-name">${productName}</div>
@@ -445,28 +464,47 @@ function renderOrderItems(items) {
     return html;
 }
 
+const ORDER_ACTIONS = {
+    pending: [
+        { status: 'paid', label: 'Mark as paid', className: 'complete' },
+        { status: 'cancelled', label: 'Cancel', className: 'cancel' }
+    ],
+    paid: [
+        { status: 'preparing', label: 'Start preparing', className: 'complete' },
+        { status: 'refunded', label: 'Refund', className: 'cancel' }
+    ],
+    preparing: [
+        { status: 'ready', label: 'Ready', className: 'complete' },
+        { status: 'refunded', label: 'Refund', className: 'cancel' }
+    ],
+    ready: [
+        { status: 'completed', label: 'Complete', className: 'complete' },
+        { status: 'refunded', label: 'Refund', className: 'cancel' }
+    ],
+    completed: [
+        { status: 'refunded', label: 'Refund', className: 'cancel' }
+    ]
+};
+
 function renderOrderActions(order) {
-    if (order.status === 'completed' || order.status === 'cancelled') {
+    const actions = ORDER_ACTIONS[order.status];
+    if (!actions) {
         return '';
     }
     
     return `
         <div class="main__order-item-actions">
-            ${order.status === 'pending' ? `
-                <button class="main__order-item-button complete" data-status="completed">Complete</button>
-                <button class="main__order-item-button cancel" data-status="cancelled">Cancel</button>
-            ` : ''}
+            ${actions.map(action => `
+                <button class="main__order-item-button ${action.className}" data-status="${action.status}">${action.label}</button>
+            `).join('')}
         </div>
     `;
 }
 
 async function updateOrderStatus(orderId, status) {
     try {
-        const response = await fetch(`/api/orders/${orderId}`, {
+        const response = await apiFetch(`/api/orders/${orderId}`, {
             method: 'PATCH',
-            headers: {
-                'Content-Type': 'application/json'
-            },
             body: JSON.stringify({ status })
         });
         
@@ -476,7 +514,7 @@ async function updateOrderStatus(orderId, status) {
         
         fetchOrders();
         
-        if (status === 'cancelled') {
+        if (status === 'cancelled' || status === 'refunded') {
             fetchProducts();
         }
     } catch (error) {
@@ -489,10 +527,20 @@ function getStatusText(status) {
     switch (status) {
         case 'pending':
             return 'Pending';
+        case 'paid':
+            return 'Paid';
+        case 'preparing':
+            return 'Preparing';
+        case 'ready':
+            return 'Ready';
         case 'completed':
             return 'Completed';
         case 'cancelled':
             return 'Cancelled';
+        case 'refunded':
+            return 'Refunded';
+        case 'failed':
+            return 'Failed';
         default:
             return status;
     }
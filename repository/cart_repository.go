@@ -0,0 +1,10 @@
+package repository
+
+import "FoodStore-AdvProg2/domain"
+
+type CartRepository interface {
+	FindItems(userID string) ([]domain.CartItem, error)
+	SaveItem(userID string, item domain.CartItem) error
+	RemoveItem(userID, productID string) (bool, error)
+	Clear(userID string) error
+}
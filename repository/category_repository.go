@@ -0,0 +1,12 @@
+package repository
+
+import "FoodStore-AdvProg2/domain"
+
+type CategoryRepository interface {
+	Save(category domain.Category) error
+	FindByID(id string) (domain.Category, error)
+	FindAll() ([]domain.Category, error)
+	Update(category domain.Category) error
+	Delete(id string) error
+	HasChildren(id string) (bool, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	Claim(record domain.IdempotencyRecord, lease time.Duration) (domain.IdempotencyRecord, bool, error)
+	FindOrderID(userID, key string) (string, error)
+	Release(userID, key string) error
+	ReleaseOrder(orderID string) error
+}
@@ -7,8 +7,9 @@ import (
 type OrderRepository interface {
 	Save(order domain.Order, items []domain.OrderItem) (string, error)
 	FindByID(id string) (domain.Order, []domain.OrderItem, error)
-	UpdateStatus(orderID, status string) error
-	FindByUserID(userID string) ([]domain.Order, error)
-	FindAll() ([]domain.Order, error)
-	DeleteOrderItemsByProduct(productID string) error
+	UpdateStatus(change domain.OrderStatusChange) error
+	FindStatusHistory(orderID string) ([]domain.OrderStatusChange, error)
+	MarkStockReleased(orderID string) error
+	FindItemsByOrderIDs(orderIDs []string) (map[string][]domain.OrderItem, error)
+	ListOrders(filter domain.OrderFilter, sort domain.SortParams, pagination domain.PaginationParams, offset int, cursor *domain.Cursor) (domain.OrderPage, error)
 }
\ No newline at end of file
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"time"
+)
+
+type OutboxRepository interface {
+	ClaimPending(limit int, lease time.Duration) ([]domain.OrderEvent, error)
+	MarkDelivered(id int64) error
+	MarkFailed(id int64, retryAt time.Time, reason string) error
+}
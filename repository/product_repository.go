@@ -1,12 +1,22 @@
 package repository
 
-import "FoodStore-AdvProg2/domain"
+import (
+    "FoodStore-AdvProg2/domain"
+    "time"
+)
 
 type ProductRepository interface {
     Save(product domain.Product) error
     FindByID(id string) (domain.Product, error)
+    FindByIDs(ids []string) ([]domain.Product, error)
     Update(id string, product domain.Product) error
-    Delete(id string) error
-    FindAllWithFilter(filter domain.FilterParams, pagination domain.PaginationParams, offset int) ([]domain.Product, int, error)
+    Archive(id string, at time.Time) error
+    Restore(id string) error
+    FindAllWithFilter(filter domain.FilterParams, sort domain.SortParams, pagination domain.PaginationParams, offset int, cursor *domain.Cursor) (domain.ProductPage, error)
     FindAll() ([]domain.Product, error)
+    Search(text string, filter domain.FilterParams, pagination domain.PaginationParams, offset int) ([]domain.SearchResult, int, error)
+    FindTags() ([]domain.TagCount, error)
+    AdjustStock(id string, delta int) error
+    ReserveStock(reservation domain.StockReservation) error
+    ReleaseStock(reference string) (bool, error)
 }
\ No newline at end of file
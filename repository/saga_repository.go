@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"time"
+)
+
+type SagaRepository interface {
+	Create(saga domain.Saga) error
+	Update(saga domain.Saga) error
+	// FindUnfinished returns running and compensating sagas last updated
+	// before updatedBefore.
+	FindUnfinished(sagaType string, updatedBefore time.Time) ([]domain.Saga, error)
+}
@@ -1,11 +1,19 @@
 package repository
 
-import "FoodStore-AdvProg2/domain"
+import (
+	"FoodStore-AdvProg2/domain"
+	"time"
+)
 
 type UserRepository interface {
 	Save(user domain.User) (string, error)
 	FindByUsername(username string) (domain.User, error)
 	FindByID(id string) (domain.User, error)
 	SaveToken(token domain.Token) error
-	FindUserIDByToken(token string) (string, error)
+	FindUserByToken(token string) (domain.User, domain.Token, error)
+	UpdateRole(userID, role string) error
+	DeleteToken(token string) (bool, error)
+	DeleteUserToken(userID, token string) (bool, error)
+	DeleteTokensByUserID(userID string) (int64, error)
+	DeleteExpiredTokens(now time.Time) (int64, error)
 }
\ No newline at end of file
@@ -0,0 +1,1318 @@
+package gateway
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/infrastructure/auth"
+	"FoodStore-AdvProg2/infrastructure/config"
+	"FoodStore-AdvProg2/infrastructure/grpc"
+	"FoodStore-AdvProg2/infrastructure/metrics"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/server"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type APIGateway struct {
+	clients  *grpc.Clients
+	verifier *auth.Verifier
+	// draining is set once shutdown starts, failing /readyz.
+	draining atomic.Bool
+}
+
+func NewAPIGateway(clients *grpc.Clients, verifier *auth.Verifier) *APIGateway {
+	return &APIGateway{clients: clients, verifier: verifier}
+}
+
+// newVerifier checks access tokens locally: with the user service's published
+// Ed25519 keys by default, or with the shared secret for HS256.
+func newVerifier(clients *grpc.Clients, cfg config.JWTConfig) *auth.Verifier {
+	switch alg := cfg.SigningAlg; alg {
+	case "", auth.AlgorithmEdDSA:
+		verifier := auth.NewEd25519Verifier(func() ([]domain.SigningKey, error) {
+			return grpc.FetchSigningKeys(clients.UserClient)
+		})
+		if err := verifier.Refresh(true); err != nil {
+			log.Printf("Warning: failed to load signing keys, will retry on first request: %v", err)
+		}
+		return verifier
+	case auth.AlgorithmHS256:
+		return auth.NewHMACVerifier([]byte(cfg.Secret))
+	default:
+		log.Fatalf("Unsupported JWT_SIGNING_ALG %q", alg)
+		return nil
+	}
+}
+
+// Serve runs the gateway against the services at their configured URLs
+// until ctx is cancelled.
+func Serve(ctx context.Context, cfg *config.Config) error {
+	clients, err := grpc.NewClients(cfg.Inventory.URL, cfg.Orders.URL, cfg.Users.URL)
+	if err != nil {
+		return fmt.Errorf("failed to initialize gRPC clients: %w", err)
+	}
+	defer clients.Close()
+
+	return Run(ctx, cfg, clients)
+}
+
+// Run serves the gateway's HTTP API on its configured port, calling the
+// services through clients, and its metrics on the metrics port. When ctx is cancelled it fails /readyz for the
+// drain delay while still serving, then stops accepting connections and
+// waits up to the shutdown timeout for requests in flight.
+func Run(ctx context.Context, cfg *config.Config, clients *grpc.Clients) error {
+	gateway := NewAPIGateway(clients, newVerifier(clients, cfg.JWT))
+	srv := &http.Server{
+		Addr:    config.ListenAddr(cfg.Gateway.Port),
+		Handler: NewRouter(gateway),
+	}
+
+	go server.ServeMetrics(ctx, cfg.Gateway.MetricsPort)
+
+	serveErr := make(chan error, 1)
+	log.Printf("API Gateway is starting on port %d...", cfg.Gateway.Port)
+	go func() { serveErr <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	gateway.draining.Store(true)
+	log.Printf("API Gateway draining for %s...", cfg.Gateway.DrainDelay.Duration)
+	select {
+	case err := <-serveErr:
+		return err
+	case <-time.After(cfg.Gateway.DrainDelay.Duration):
+	}
+
+	log.Println("Shutting down API Gateway...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout.Duration)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("requests still running after %s: %w", cfg.ShutdownTimeout.Duration, err)
+	}
+	return nil
+}
+
+// NewRouter registers the gateway's pages and API routes.
+func NewRouter(gateway *APIGateway) *gin.Engine {
+	r := gin.Default()
+
+	// Probes, registered before the middleware below so that they are
+	// neither authenticated, logged by it nor counted as API requests.
+	r.GET("/healthz", gateway.Healthz)
+	r.GET("/readyz", gateway.Readyz)
+
+	// Middleware
+	r.Use(gin.Logger())
+	r.Use(gin.Recovery())
+	r.Use(gateway.MetricsMiddleware())
+	r.Use(gateway.AuthMiddleware())
+
+	// Static files and HTML
+	r.Static("/static", "./public")
+	r.LoadHTMLGlob("public/*.html")
+	r.GET("/admin", func(c *gin.Context) {
+		c.HTML(http.StatusOK, "admin.html", nil)
+	})
+	r.GET("/order", func(c *gin.Context) {
+		c.HTML(http.StatusOK, "order.html", nil)
+	})
+
+	// Inventory API
+	inventoryAPI := r.Group("/api/products")
+	{
+		inventoryAPI.GET("", gateway.ListProducts)
+		inventoryAPI.GET("/search", gateway.SearchProducts)
+		inventoryAPI.GET("/:id", gateway.GetProduct)
+	}
+	inventoryAdminAPI := r.Group("/api/products", gateway.RequireRoles(domain.RoleStaff, domain.RoleAdmin))
+	{
+		inventoryAdminAPI.POST("", gateway.CreateProduct)
+		inventoryAdminAPI.PUT("/:id", gateway.UpdateProduct)
+		inventoryAdminAPI.DELETE("/:id", gateway.DeleteProduct)
+		inventoryAdminAPI.POST("/:id/restore", gateway.RestoreProduct)
+	}
+	r.GET("/api/tags", gateway.ListTags)
+
+	// Category API
+	categoryAPI := r.Group("/api/categories")
+	{
+		categoryAPI.GET("", gateway.ListCategories)
+		categoryAPI.GET("/:id", gateway.GetCategory)
+	}
+	categoryAdminAPI := r.Group("/api/categories", gateway.RequireRoles(domain.RoleStaff, domain.RoleAdmin))
+	{
+		categoryAdminAPI.POST("", gateway.CreateCategory)
+		categoryAdminAPI.PUT("/:id", gateway.UpdateCategory)
+		categoryAdminAPI.DELETE("/:id", gateway.DeleteCategory)
+	}
+
+	// Order API
+	orderAPI := r.Group("/api/orders")
+	{
+		orderAPI.POST("", gateway.CreateOrder)
+		orderAPI.GET("", gateway.ListOrders)
+		orderAPI.GET("/:id", gateway.GetOrder)
+		orderAPI.GET("/:id/history", gateway.GetOrderHistory)
+		orderAPI.POST("/:id/cancel", gateway.CancelOrder)
+	}
+	orderAdminAPI := r.Group("/api/orders", gateway.RequireRoles(domain.RoleStaff, domain.RoleAdmin))
+	{
+		orderAdminAPI.PATCH("/:id", gateway.UpdateOrderStatus)
+	}
+
+	// Cart API
+	cartAPI := r.Group("/api/cart")
+	{
+		cartAPI.GET("", gateway.GetCart)
+		cartAPI.DELETE("", gateway.ClearCart)
+		cartAPI.POST("/items", gateway.AddCartItem)
+		cartAPI.PUT("/items/:product_id", gateway.UpdateCartItem)
+		cartAPI.DELETE("/items/:product_id", gateway.RemoveCartItem)
+		cartAPI.POST("/checkout", gateway.CheckoutCart)
+	}
+
+	// User API
+	userAPI := r.Group("/api/users")
+	{
+		userAPI.POST("/register", gateway.RegisterUser)
+		userAPI.POST("/login", gateway.AuthenticateUser)
+		userAPI.POST("/refresh", gateway.RefreshToken)
+		userAPI.POST("/logout", gateway.Logout)
+		userAPI.POST("/logout-all", gateway.LogoutAll)
+	}
+	userAdminAPI := r.Group("/api/users", gateway.RequireRoles(domain.RoleAdmin))
+	{
+		userAdminAPI.PUT("/:id/role", gateway.SetUserRole)
+		userAdminAPI.DELETE("/:id/sessions", gateway.RevokeUserSessions)
+	}
+
+	return r
+}
+
+// httpStatus translates a gRPC error from a downstream service into the
+// matching HTTP status code.
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.FailedPrecondition, codes.Aborted, codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// MetricsMiddleware records each request's count and latency by matched
+// route, so that IDs in paths do not create a series each.
+func (g *APIGateway) MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
+
+func (g *APIGateway) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		path := strings.TrimSuffix(c.Request.URL.Path, "/")
+		log.Printf("Processing request: %s %s", c.Request.Method, path)
+
+		if path == "/api/users/register" || path == "/api/users/login" || path == "/api/users/refresh" {
+			log.Printf("Skipping auth for open endpoint: %s", path)
+			c.Next()
+			return
+		}
+
+		token := c.GetHeader("Authorization")
+		if token == "" {
+			log.Printf("No Authorization token provided for %s", path)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization token required"})
+			c.Abort()
+			return
+		}
+
+		claims, err := g.verifier.Verify(token)
+		if err != nil {
+			log.Printf("Invalid token for %s: %v", path, err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		log.Printf("Valid token for user_id: %s (role %s)", claims.UserID, claims.Role)
+		c.Set("user_id", claims.UserID)
+		c.Set("role", claims.Role)
+		c.Next()
+	}
+}
+
+// RequireRoles rejects requests from users whose role is not in roles. It must
+// run after AuthMiddleware.
+func (g *APIGateway) RequireRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+
+		log.Printf("Role %q is not allowed to access %s %s", role, c.Request.Method, c.Request.URL.Path)
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+	}
+}
+
+func isStaff(c *gin.Context) bool {
+	switch c.GetString("role") {
+	case domain.RoleStaff, domain.RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// canAccessOrder reports whether the caller may see an order owned by ownerID.
+// Customers only see their own orders; staff and admins see all of them.
+func canAccessOrder(c *gin.Context, ownerID string) bool {
+	return isStaff(c) || c.GetString("user_id") == ownerID
+}
+
+// productRequest accepts the price either in minor units (price_amount plus
+// currency) or, for older clients, as a decimal price in the default currency.
+type productRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Price       float64  `json:"price" binding:"omitempty,gt=0"`
+	PriceAmount int64    `json:"price_amount" binding:"omitempty,gt=0"`
+	Currency    string   `json:"currency" binding:"omitempty,len=3"`
+	Stock       int32    `json:"stock" binding:"required,gte=0"`
+	Description string   `json:"description"`
+	ImageURL    string   `json:"image_url" binding:"omitempty,url"`
+	Unit        string   `json:"unit" binding:"omitempty,oneof=piece kg litre"`
+	CategoryID  string   `json:"category_id" binding:"omitempty,uuid"`
+	Tags        []string `json:"tags"`
+	Allergens   []string `json:"allergens"`
+}
+
+// productMessage is implemented by every inventory message that carries a
+// full product.
+type productMessage interface {
+	GetId() string
+	GetName() string
+	GetPrice() float64
+	GetStock() int32
+	GetPriceAmount() int64
+	GetCurrency() string
+	GetDescription() string
+	GetImageUrl() string
+	GetUnit() string
+	GetCategoryId() string
+	GetTags() []string
+	GetAllergens() []string
+}
+
+func productJSON(p productMessage) gin.H {
+	product := gin.H{
+		"id":           p.GetId(),
+		"name":         p.GetName(),
+		"price":        p.GetPrice(),
+		"stock":        p.GetStock(),
+		"price_amount": p.GetPriceAmount(),
+		"currency":     p.GetCurrency(),
+		"description":  p.GetDescription(),
+		"image_url":    p.GetImageUrl(),
+		"unit":         p.GetUnit(),
+		"category_id":  p.GetCategoryId(),
+		"tags":         nonNil(p.GetTags()),
+		"allergens":    nonNil(p.GetAllergens()),
+	}
+	if archived, ok := p.(interface{ GetArchivedAt() int64 }); ok && archived.GetArchivedAt() != 0 {
+		product["archived_at"] = archived.GetArchivedAt()
+	}
+	return product
+}
+
+// nonNil makes empty lists encode as [] rather than null.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// splitList reads a comma-separated query parameter such as tags=vegan,spicy.
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func (g *APIGateway) CreateProduct(c *gin.Context) {
+	var req productRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Price == 0 && req.PriceAmount == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "price or price_amount is required"})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		log.Printf("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+	log.Printf("Creating product by user_id: %s", userID)
+
+	resp, err := g.clients.InventoryClient.CreateProduct(context.Background(), &proto.CreateProductRequest{
+		Name:        req.Name,
+		Price:       req.Price,
+		Stock:       req.Stock,
+		PriceAmount: req.PriceAmount,
+		Currency:    req.Currency,
+		Description: req.Description,
+		ImageUrl:    req.ImageURL,
+		Unit:        req.Unit,
+		CategoryId:  req.CategoryID,
+		Tags:        req.Tags,
+		Allergens:   req.Allergens,
+	})
+	if err != nil {
+		log.Printf("Failed to create product: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"id": resp.Id})
+}
+
+func (g *APIGateway) GetProduct(c *gin.Context) {
+	id := c.Param("id")
+	log.Printf("Fetching product with id: %s", id)
+
+	resp, err := g.clients.InventoryClient.GetProduct(context.Background(), &proto.GetProductRequest{Id: id})
+	if err != nil {
+		log.Printf("Failed to get product: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, productJSON(resp))
+}
+
+func (g *APIGateway) UpdateProduct(c *gin.Context) {
+	id := c.Param("id")
+	var req productRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Price == 0 && req.PriceAmount == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "price or price_amount is required"})
+		return
+	}
+
+	log.Printf("Updating product with id: %s", id)
+	resp, err := g.clients.InventoryClient.UpdateProduct(context.Background(), &proto.UpdateProductRequest{
+		Id:          id,
+		Name:        req.Name,
+		Price:       req.Price,
+		Stock:       req.Stock,
+		PriceAmount: req.PriceAmount,
+		Currency:    req.Currency,
+		Description: req.Description,
+		ImageUrl:    req.ImageURL,
+		Unit:        req.Unit,
+		CategoryId:  req.CategoryID,
+		Tags:        req.Tags,
+		Allergens:   req.Allergens,
+	})
+	if err != nil {
+		log.Printf("Failed to update product: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, productJSON(resp))
+}
+
+func (g *APIGateway) DeleteProduct(c *gin.Context) {
+	id := c.Param("id")
+	log.Printf("Deleting product with id: %s", id)
+
+	ctx := context.Background()
+
+	// The product is archived, not removed, and orders that contain it are
+	// left as they are.
+	_, err := g.clients.InventoryClient.DeleteProduct(ctx, &proto.DeleteProductRequest{Id: id})
+	if err != nil {
+		log.Printf("Failed to delete product: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (g *APIGateway) RestoreProduct(c *gin.Context) {
+	id := c.Param("id")
+	log.Printf("Restoring product with id: %s", id)
+
+	_, err := g.clients.InventoryClient.RestoreProduct(context.Background(), &proto.RestoreProductRequest{Id: id})
+	if err != nil {
+		log.Printf("Failed to restore product: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id, "status": "restored"})
+}
+
+// productQuery holds the query parameters shared by product listing and
+// search.
+type productQuery struct {
+	Name           string  `form:"name"`
+	MinPrice       float64 `form:"min_price"`
+	MaxPrice       float64 `form:"max_price"`
+	MinPriceAmount int64   `form:"min_price_amount"`
+	MaxPriceAmount int64   `form:"max_price_amount"`
+	Currency       string  `form:"currency"`
+	Page           int32   `form:"page"`
+	PerPage        int32   `form:"per_page"`
+	// IncludeArchived lists archived products too; staff and admins only.
+	IncludeArchived  bool   `form:"include_archived"`
+	CategoryID       string `form:"category_id" binding:"omitempty,uuid"`
+	Tags             string `form:"tags"`
+	ExcludeAllergens string `form:"exclude_allergens"`
+	Unit             string `form:"unit"`
+}
+
+func (q *productQuery) filter() *proto.FilterParams {
+	return &proto.FilterParams{
+		Name:             q.Name,
+		MinPrice:         q.MinPrice,
+		MaxPrice:         q.MaxPrice,
+		MinPriceAmount:   q.MinPriceAmount,
+		MaxPriceAmount:   q.MaxPriceAmount,
+		Currency:         q.Currency,
+		IncludeArchived:  q.IncludeArchived,
+		CategoryId:       q.CategoryID,
+		Tags:             splitList(q.Tags),
+		ExcludeAllergens: splitList(q.ExcludeAllergens),
+		Unit:             q.Unit,
+	}
+}
+
+func (q *productQuery) pagination() *proto.PaginationParams {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PerPage < 1 {
+		q.PerPage = 10
+	}
+	return &proto.PaginationParams{Page: q.Page, PerPage: q.PerPage}
+}
+
+// ListProducts pages by page number, or by the opaque next_cursor and
+// prev_cursor of an earlier response, which stay fast on deep pages.
+func (g *APIGateway) ListProducts(c *gin.Context) {
+	var req struct {
+		productQuery
+		SortBy  string `form:"sort_by" binding:"omitempty,oneof=price name stock created_at"`
+		SortDir string `form:"sort_dir" binding:"omitempty,oneof=asc desc"`
+		Cursor  string `form:"cursor"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Printf("Invalid query params: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.IncludeArchived && !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+	pagination := req.pagination()
+
+	log.Printf("Listing products with filter: name=%s, min_price=%f, max_price=%f, page=%d, per_page=%d",
+		req.Name, req.MinPrice, req.MaxPrice, req.Page, req.PerPage)
+
+	resp, err := g.clients.InventoryClient.ListProducts(context.Background(), &proto.ListProductsRequest{
+		Filter:     req.filter(),
+		Pagination: pagination,
+		SortBy:     req.SortBy,
+		SortDir:    req.SortDir,
+		Cursor:     req.Cursor,
+	})
+	if err != nil {
+		log.Printf("Failed to list products: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	products := make([]gin.H, len(resp.Products))
+	for i, p := range resp.Products {
+		products[i] = productJSON(p)
+	}
+
+	body := gin.H{
+		"products":    products,
+		"per_page":    resp.PerPage,
+		"next_cursor": resp.NextCursor,
+		"prev_cursor": resp.PrevCursor,
+	}
+	// Pages read by cursor are not numbered or counted.
+	if req.Cursor == "" {
+		body["total"] = resp.Total
+		body["page"] = resp.Page
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// SearchProducts takes the search text in q and accepts the same filters as
+// ListProducts. Results come most relevant first.
+func (g *APIGateway) SearchProducts(c *gin.Context) {
+	var req struct {
+		Query string `form:"q" binding:"required"`
+		productQuery
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Printf("Invalid query params: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.IncludeArchived && !isStaff(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+
+	log.Printf("Searching products for %q, page=%d, per_page=%d", req.Query, req.Page, req.PerPage)
+
+	resp, err := g.clients.InventoryClient.SearchProducts(context.Background(), &proto.SearchProductsRequest{
+		Query:      req.Query,
+		Filter:     req.filter(),
+		Pagination: req.pagination(),
+	})
+	if err != nil {
+		log.Printf("Failed to search products: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	results := make([]gin.H, len(resp.Results))
+	for i, res := range resp.Results {
+		results[i] = gin.H{
+			"product":        productJSON(res.Product),
+			"rank":           res.Rank,
+			"name_highlight": res.NameHighlight,
+			"snippet":        res.Snippet,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"results":  results,
+		"total":    resp.Total,
+		"page":     resp.Page,
+		"per_page": resp.PerPage,
+	})
+}
+
+func (g *APIGateway) ListTags(c *gin.Context) {
+	resp, err := g.clients.InventoryClient.ListTags(context.Background(), &proto.ListTagsRequest{})
+	if err != nil {
+		log.Printf("Failed to list tags: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	tags := make([]gin.H, len(resp.Tags))
+	for i, t := range resp.Tags {
+		tags[i] = gin.H{"tag": t.Tag, "count": t.Count}
+	}
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// Category Handlers
+type categoryRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ParentID string `json:"parent_id" binding:"omitempty,uuid"`
+}
+
+func categoryJSON(category *proto.Category) gin.H {
+	return gin.H{
+		"id":        category.Id,
+		"name":      category.Name,
+		"parent_id": category.ParentId,
+	}
+}
+
+func (g *APIGateway) ListCategories(c *gin.Context) {
+	resp, err := g.clients.InventoryClient.ListCategories(context.Background(), &proto.ListCategoriesRequest{})
+	if err != nil {
+		log.Printf("Failed to list categories: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	categories := make([]gin.H, len(resp.Categories))
+	for i, category := range resp.Categories {
+		categories[i] = categoryJSON(category)
+	}
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+func (g *APIGateway) GetCategory(c *gin.Context) {
+	resp, err := g.clients.InventoryClient.GetCategory(context.Background(), &proto.GetCategoryRequest{Id: c.Param("id")})
+	if err != nil {
+		log.Printf("Failed to get category: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, categoryJSON(resp))
+}
+
+func (g *APIGateway) CreateCategory(c *gin.Context) {
+	var req categoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.clients.InventoryClient.CreateCategory(context.Background(), &proto.CreateCategoryRequest{
+		Name:     req.Name,
+		ParentId: req.ParentID,
+	})
+	if err != nil {
+		log.Printf("Failed to create category: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusCreated, categoryJSON(resp))
+}
+
+func (g *APIGateway) UpdateCategory(c *gin.Context) {
+	var req categoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.clients.InventoryClient.UpdateCategory(context.Background(), &proto.Category{
+		Id:       c.Param("id"),
+		Name:     req.Name,
+		ParentId: req.ParentID,
+	})
+	if err != nil {
+		log.Printf("Failed to update category: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, categoryJSON(resp))
+}
+
+func (g *APIGateway) DeleteCategory(c *gin.Context) {
+	id := c.Param("id")
+	_, err := g.clients.InventoryClient.DeleteCategory(context.Background(), &proto.DeleteCategoryRequest{Id: id})
+	if err != nil {
+		log.Printf("Failed to delete category: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": id, "status": "deleted"})
+}
+
+// Order Handlers
+func (g *APIGateway) CreateOrder(c *gin.Context) {
+	var req struct {
+		Items []struct {
+			ProductID string `json:"product_id" binding:"required"`
+			Quantity  int32  `json:"quantity" binding:"required,gt=0"`
+		} `json:"items" binding:"required,dive"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		log.Printf("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+	log.Printf("Creating order for user_id: %s", userID)
+
+	items := make([]*proto.OrderItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &proto.OrderItemRequest{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	resp, err := g.clients.OrderClient.CreateOrder(context.Background(), &proto.CreateOrderRequest{
+		UserId:         userID.(string),
+		Items:          items,
+		IdempotencyKey: c.GetHeader("Idempotency-Key"),
+	})
+	if err != nil {
+		log.Printf("Failed to create order: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"order_id": resp.OrderId})
+}
+
+func (g *APIGateway) GetOrder(c *gin.Context) {
+	id := c.Param("id")
+	log.Printf("Fetching order with id: %s", id)
+
+	resp, err := g.clients.OrderClient.GetOrder(context.Background(), &proto.GetOrderRequest{OrderId: id})
+	if err != nil {
+		log.Printf("Failed to get order: %v", err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return
+	}
+	if !canAccessOrder(c, resp.UserId) {
+		log.Printf("User %s is not allowed to view order %s", c.GetString("user_id"), id)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, orderJSON(resp))
+}
+
+// orderJSON renders an order. Amounts appear both in minor units
+// (*_amount plus currency) and, for older clients, as decimal numbers.
+func orderJSON(order *proto.OrderResponse) gin.H {
+	items := make([]gin.H, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = gin.H{
+			"id":           item.Id,
+			"order_id":     item.OrderId,
+			"product_id":   item.ProductId,
+			"product_name": item.ProductName,
+			"quantity":     item.Quantity,
+			"price":        item.Price,
+			"price_amount": item.PriceAmount,
+			"currency":     item.Currency,
+		}
+	}
+
+	return gin.H{
+		"id":           order.Id,
+		"user_id":      order.UserId,
+		"total_price":  order.TotalPrice,
+		"total_amount": order.TotalAmount,
+		"currency":     order.Currency,
+		"status":       order.Status,
+		"created_at":   order.CreatedAt,
+		"items":        items,
+	}
+}
+
+func (g *APIGateway) UpdateOrderStatus(c *gin.Context) {
+	id := c.Param("id")
+	var req struct {
+		Status string `json:"status" binding:"required,oneof=paid preparing ready completed cancelled refunded"`
+		Reason string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		log.Printf("User ID not found in context")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+		return
+	}
+
+	log.Printf("Updating order status for id: %s to %s by user_id: %s", id, req.Status, userID)
+	_, err := g.clients.OrderClient.UpdateOrderStatus(context.Background(), &proto.UpdateOrderStatusRequest{
+		OrderId: id,
+		Status:  req.Status,
+		ActorId: userID.(string),
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		log.Printf("Failed to update order status: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "updated"})
+}
+
+// CancelOrder lets customers cancel their own orders without the staff-only
+// status endpoint.
+func (g *APIGateway) CancelOrder(c *gin.Context) {
+	id := c.Param("id")
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.Printf("Invalid request body: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	order, err := g.clients.OrderClient.GetOrder(context.Background(), &proto.GetOrderRequest{OrderId: id})
+	if err != nil || !canAccessOrder(c, order.UserId) {
+		log.Printf("Order %s not available to user %s: %v", id, c.GetString("user_id"), err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return
+	}
+
+	log.Printf("Cancelling order %s by user_id: %s", id, c.GetString("user_id"))
+	_, err = g.clients.OrderClient.UpdateOrderStatus(context.Background(), &proto.UpdateOrderStatusRequest{
+		OrderId: id,
+		Status:  domain.OrderStatusCancelled,
+		ActorId: c.GetString("user_id"),
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		log.Printf("Failed to cancel order: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "cancelled"})
+}
+
+func (g *APIGateway) GetOrderHistory(c *gin.Context) {
+	id := c.Param("id")
+	log.Printf("Fetching status history for order id: %s", id)
+
+	order, err := g.clients.OrderClient.GetOrder(context.Background(), &proto.GetOrderRequest{OrderId: id})
+	if err != nil || !canAccessOrder(c, order.UserId) {
+		log.Printf("Order %s not available to user %s: %v", id, c.GetString("user_id"), err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Order not found"})
+		return
+	}
+
+	resp, err := g.clients.OrderClient.GetOrderHistory(context.Background(), &proto.GetOrderHistoryRequest{OrderId: id})
+	if err != nil {
+		log.Printf("Failed to get order history: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	history := make([]gin.H, len(resp.Changes))
+	for i, change := range resp.Changes {
+		history[i] = gin.H{
+			"old_status": change.OldStatus,
+			"new_status": change.NewStatus,
+			"actor_id":   change.ActorId,
+			"reason":     change.Reason,
+			"changed_at": change.ChangedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"order_id": id, "history": history})
+}
+
+// parseTimeParam reads a query parameter given either as an RFC 3339
+// timestamp or as a date, which means midnight UTC. It returns 0 when the
+// parameter is empty.
+func parseTimeParam(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: use RFC 3339 or YYYY-MM-DD", value)
+	}
+	return t.Unix(), nil
+}
+
+// ListOrders lists the caller's orders. Staff and admins see every order and
+// may narrow the listing to one customer with user_id.
+func (g *APIGateway) ListOrders(c *gin.Context) {
+	var req struct {
+		UserID         string `form:"user_id"`
+		Status         string `form:"status"`
+		CreatedFrom    string `form:"created_from"`
+		CreatedTo      string `form:"created_to"`
+		MinTotalAmount int64  `form:"min_total_amount" binding:"omitempty,gt=0"`
+		MaxTotalAmount int64  `form:"max_total_amount" binding:"omitempty,gt=0"`
+		Currency       string `form:"currency" binding:"omitempty,len=3"`
+		SortBy         string `form:"sort_by" binding:"omitempty,oneof=created_at total"`
+		SortDir        string `form:"sort_dir" binding:"omitempty,oneof=asc desc"`
+		Page           int32  `form:"page"`
+		PerPage        int32  `form:"per_page"`
+		Cursor         string `form:"cursor"`
+		IncludeItems   bool   `form:"include_items"`
+	}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		log.Printf("Invalid query params: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	createdFrom, err := parseTimeParam(req.CreatedFrom)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "created_from: " + err.Error()})
+		return
+	}
+	createdTo, err := parseTimeParam(req.CreatedTo)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "created_to: " + err.Error()})
+		return
+	}
+
+	userID := c.GetString("user_id")
+	if isStaff(c) {
+		userID = req.UserID
+	} else if req.UserID != "" && req.UserID != userID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return
+	}
+	log.Printf("Listing orders for user_id=%q, status=%q, page=%d, per_page=%d", userID, req.Status, req.Page, req.PerPage)
+
+	resp, err := g.clients.OrderClient.ListOrders(context.Background(), &proto.ListOrdersRequest{
+		Filter: &proto.OrderFilter{
+			UserId:         userID,
+			Statuses:       splitList(req.Status),
+			CreatedFrom:    createdFrom,
+			CreatedTo:      createdTo,
+			MinTotalAmount: req.MinTotalAmount,
+			MaxTotalAmount: req.MaxTotalAmount,
+			Currency:       req.Currency,
+		},
+		Page:         req.Page,
+		PerPage:      req.PerPage,
+		SortBy:       req.SortBy,
+		SortDir:      req.SortDir,
+		Cursor:       req.Cursor,
+		IncludeItems: req.IncludeItems,
+	})
+	if err != nil {
+		log.Printf("Failed to list orders: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	orders := make([]gin.H, len(resp.Orders))
+	for i, order := range resp.Orders {
+		orders[i] = orderJSON(order)
+	}
+
+	body := gin.H{
+		"orders":      orders,
+		"per_page":    resp.PerPage,
+		"next_cursor": resp.NextCursor,
+		"prev_cursor": resp.PrevCursor,
+	}
+	// Pages read by cursor are not numbered or counted.
+	if req.Cursor == "" {
+		body["total"] = resp.Total
+		body["page"] = resp.Page
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// Cart Handlers
+func cartJSON(cart *proto.Cart) gin.H {
+	items := make([]gin.H, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = gin.H{
+			"product_id":           item.ProductId,
+			"name":                 item.Name,
+			"quantity":             item.Quantity,
+			"price":                item.Price,
+			"current_price":        item.CurrentPrice,
+			"price_amount":         item.PriceAmount,
+			"current_price_amount": item.CurrentPriceAmount,
+			"currency":             item.Currency,
+			"stock":                item.Stock,
+			"problem":              item.Problem,
+			"added_at":             item.AddedAt,
+		}
+	}
+
+	return gin.H{
+		"user_id":      cart.UserId,
+		"items":        items,
+		"total_price":  cart.TotalPrice,
+		"total_amount": cart.TotalAmount,
+		"currency":     cart.Currency,
+	}
+}
+
+func (g *APIGateway) respondCart(c *gin.Context, cart *proto.Cart, err error) {
+	if err != nil {
+		log.Printf("Cart request failed: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	c.JSON(http.StatusOK, cartJSON(cart))
+}
+
+func (g *APIGateway) GetCart(c *gin.Context) {
+	cart, err := g.clients.CartClient.GetCart(context.Background(), &proto.GetCartRequest{
+		UserId: c.GetString("user_id"),
+	})
+	g.respondCart(c, cart, err)
+}
+
+func (g *APIGateway) AddCartItem(c *gin.Context) {
+	var req struct {
+		ProductID string `json:"product_id" binding:"required"`
+		Quantity  int32  `json:"quantity" binding:"required,gt=0"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := g.clients.CartClient.AddCartItem(context.Background(), &proto.AddCartItemRequest{
+		UserId:    c.GetString("user_id"),
+		ProductId: req.ProductID,
+		Quantity:  req.Quantity,
+	})
+	g.respondCart(c, cart, err)
+}
+
+func (g *APIGateway) UpdateCartItem(c *gin.Context) {
+	var req struct {
+		Quantity *int32 `json:"quantity" binding:"required,gte=0"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cart, err := g.clients.CartClient.UpdateCartItem(context.Background(), &proto.UpdateCartItemRequest{
+		UserId:    c.GetString("user_id"),
+		ProductId: c.Param("product_id"),
+		Quantity:  *req.Quantity,
+	})
+	g.respondCart(c, cart, err)
+}
+
+func (g *APIGateway) RemoveCartItem(c *gin.Context) {
+	cart, err := g.clients.CartClient.RemoveCartItem(context.Background(), &proto.RemoveCartItemRequest{
+		UserId:    c.GetString("user_id"),
+		ProductId: c.Param("product_id"),
+	})
+	g.respondCart(c, cart, err)
+}
+
+func (g *APIGateway) ClearCart(c *gin.Context) {
+	cart, err := g.clients.CartClient.ClearCart(context.Background(), &proto.ClearCartRequest{
+		UserId: c.GetString("user_id"),
+	})
+	g.respondCart(c, cart, err)
+}
+
+func (g *APIGateway) CheckoutCart(c *gin.Context) {
+	userID := c.GetString("user_id")
+	resp, err := g.clients.CartClient.CheckoutCart(context.Background(), &proto.CheckoutCartRequest{
+		UserId:         userID,
+		IdempotencyKey: c.GetHeader("Idempotency-Key"),
+	})
+	if err != nil {
+		log.Printf("Failed to check out cart of user %s: %v", userID, err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"order_id": resp.OrderId})
+}
+
+// User Handlers
+func (g *APIGateway) SetUserRole(c *gin.Context) {
+	id := c.Param("id")
+	var req struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Printf("Setting role of user %s to %s", id, req.Role)
+	resp, err := g.clients.UserClient.SetUserRole(context.Background(), &proto.SetUserRoleRequest{
+		UserId: id,
+		Role:   req.Role,
+	})
+	if err != nil {
+		log.Printf("Failed to set user role: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": resp.UserId, "role": resp.Role})
+}
+
+func (g *APIGateway) RegisterUser(c *gin.Context) {
+	var req struct {
+		Username string `json:"username" binding:"required"`
+		Password string `json:"password" binding:"required,min=6"`
+		Email    string `json:"email" binding:"required,email"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Printf("Registering user: %s", req.Username)
+	resp, err := g.clients.UserClient.Register(context.Background(), &proto.RegisterRequest{
+		Username: req.Username,
+		Password: req.Password,
+		Email:    req.Email,
+	})
+	if err != nil {
+		log.Printf("Failed to register user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"user_id": resp.UserId})
+}
+
+func (g *APIGateway) AuthenticateUser(c *gin.Context) {
+	var req struct {
+		Username string `json:"username" binding:"required"`
+		Password string `json:"password" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	log.Printf("Authenticating user: %s", req.Username)
+	resp, err := g.clients.UserClient.Authenticate(context.Background(), &proto.AuthenticateRequest{
+		Username: req.Username,
+		Password: req.Password,
+	})
+	if err != nil {
+		log.Printf("Failed to authenticate user: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":              resp.Token,
+		"user_id":            resp.UserId,
+		"expires_at":         resp.ExpiresAt,
+		"refresh_token":      resp.RefreshToken,
+		"refresh_expires_at": resp.RefreshExpiresAt,
+	})
+}
+
+func (g *APIGateway) RefreshToken(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := g.clients.UserClient.RefreshToken(context.Background(), &proto.RefreshTokenRequest{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		log.Printf("Failed to refresh token: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":              resp.Token,
+		"user_id":            resp.UserId,
+		"expires_at":         resp.ExpiresAt,
+		"refresh_token":      resp.RefreshToken,
+		"refresh_expires_at": resp.RefreshExpiresAt,
+	})
+}
+
+func (g *APIGateway) Logout(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Printf("Invalid request body: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, err := g.clients.UserClient.RevokeToken(context.Background(), &proto.RevokeTokenRequest{
+		Token:  req.RefreshToken,
+		UserId: c.GetString("user_id"),
+	})
+	if err != nil {
+		log.Printf("Failed to revoke token: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "logged out"})
+}
+
+func (g *APIGateway) LogoutAll(c *gin.Context) {
+	g.revokeAllSessions(c, c.GetString("user_id"))
+}
+
+func (g *APIGateway) RevokeUserSessions(c *gin.Context) {
+	g.revokeAllSessions(c, c.Param("id"))
+}
+
+func (g *APIGateway) revokeAllSessions(c *gin.Context, userID string) {
+	log.Printf("Revoking all sessions of user %s", userID)
+	resp, err := g.clients.UserClient.RevokeAllTokens(context.Background(), &proto.RevokeAllTokensRequest{UserId: userID})
+	if err != nil {
+		log.Printf("Failed to revoke sessions: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": userID, "revoked": resp.Revoked})
+}
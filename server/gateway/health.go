@@ -0,0 +1,69 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const readinessTimeout = 2 * time.Second
+
+// Healthz reports that the gateway process is up. It does not call the
+// services, so a failing dependency never gets the gateway restarted.
+func (g *APIGateway) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz checks every service's grpc.health.v1 status and is ready only when
+// all of them are SERVING. It is never ready once the gateway is draining.
+func (g *APIGateway) Readyz(c *gin.Context) {
+	if g.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "draining"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		ready    = true
+		services = gin.H{}
+	)
+	for name, client := range g.clients.Health {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+
+			detail := gin.H{"latency_ms": time.Since(start).Milliseconds()}
+			if err != nil {
+				detail["status"] = healthpb.HealthCheckResponse_UNKNOWN.String()
+				detail["error"] = status.Convert(err).Message()
+			} else {
+				detail["status"] = resp.Status.String()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			services[name] = detail
+			if err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+				ready = false
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !ready {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "services": services})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ready", "services": services})
+}
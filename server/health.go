@@ -0,0 +1,124 @@
+package server
+
+import (
+	"FoodStore-AdvProg2/infrastructure/postgres"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	healthInterval = 5 * time.Second
+	healthTimeout  = 2 * time.Second
+)
+
+// Check reports whether a dependency of a service is usable.
+type Check func(ctx context.Context) error
+
+type dependency struct {
+	name    string
+	check   Check
+	failing bool
+}
+
+// Health serves grpc.health.v1 on a gRPC server. Each dependency's status is
+// published under its name, such as "postgres" or "users". The server as a
+// whole ("") and each of its gRPC services are SERVING only while every
+// dependency is.
+type Health struct {
+	server       *health.Server
+	services     []string
+	dependencies []*dependency
+}
+
+// NewHealth registers the health service on s, reporting NOT_SERVING until
+// the first checks pass. Register the other services on s first.
+func NewHealth(s *grpc.Server) *Health {
+	h := &Health{server: health.NewServer()}
+	for name := range s.GetServiceInfo() {
+		h.services = append(h.services, name)
+	}
+	healthpb.RegisterHealthServer(s, h.server)
+	h.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return h
+}
+
+func (h *Health) AddCheck(name string, check Check) {
+	h.dependencies = append(h.dependencies, &dependency{name: name, check: check})
+	h.server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// Run checks every dependency every few seconds until ctx is cancelled. It
+// then reports NOT_SERVING for everything, so that clients stop sending
+// requests while the server drains.
+func (h *Health) Run(ctx context.Context) {
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+
+	for {
+		h.checkAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			h.server.Shutdown()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Health) checkAll(ctx context.Context) {
+	overall := healthpb.HealthCheckResponse_SERVING
+	for _, dep := range h.dependencies {
+		checkCtx, cancel := context.WithTimeout(ctx, healthTimeout)
+		err := dep.check(checkCtx)
+		cancel()
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			overall = status
+			if !dep.failing {
+				log.Printf("Health check %s failing: %v", dep.name, err)
+			}
+		} else if dep.failing {
+			log.Printf("Health check %s passing again", dep.name)
+		}
+		dep.failing = err != nil
+		h.server.SetServingStatus(dep.name, status)
+	}
+	h.setStatus(overall)
+}
+
+func (h *Health) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	h.server.SetServingStatus("", status)
+	for _, service := range h.services {
+		h.server.SetServingStatus(service, status)
+	}
+}
+
+// PingDatabase checks that postgres.DB can reach the database.
+func PingDatabase(ctx context.Context) error {
+	return postgres.DB.Ping(ctx)
+}
+
+// ServiceHealth checks a downstream service through its own health service,
+// so it fails both when the service is unreachable and when it is unhealthy.
+func ServiceHealth(conn grpc.ClientConnInterface) Check {
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("status %s", resp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,63 @@
+package inventory
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/proto"
+	"context"
+)
+
+func toProtoCategory(category domain.Category) *proto.Category {
+	return &proto.Category{
+		Id:       category.ID,
+		Name:     category.Name,
+		ParentId: category.ParentID,
+	}
+}
+
+func (s *inventoryServer) CreateCategory(ctx context.Context, req *proto.CreateCategoryRequest) (*proto.Category, error) {
+	category, err := s.categories.Create(req.Name, req.ParentId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoCategory(category), nil
+}
+
+func (s *inventoryServer) GetCategory(ctx context.Context, req *proto.GetCategoryRequest) (*proto.Category, error) {
+	category, err := s.categories.GetByID(req.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoCategory(category), nil
+}
+
+func (s *inventoryServer) UpdateCategory(ctx context.Context, req *proto.Category) (*proto.Category, error) {
+	category, err := s.categories.Update(domain.Category{
+		ID:       req.Id,
+		Name:     req.Name,
+		ParentID: req.ParentId,
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoCategory(category), nil
+}
+
+func (s *inventoryServer) DeleteCategory(ctx context.Context, req *proto.DeleteCategoryRequest) (*proto.DeleteCategoryResponse, error) {
+	if err := s.categories.Delete(req.Id); err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.DeleteCategoryResponse{Success: true}, nil
+}
+
+func (s *inventoryServer) ListCategories(ctx context.Context, req *proto.ListCategoriesRequest) (*proto.ListCategoriesResponse, error) {
+	categories, err := s.categories.List()
+	if err != nil {
+		return nil, err
+	}
+
+	protoCategories := make([]*proto.Category, len(categories))
+	for i, category := range categories {
+		protoCategories[i] = toProtoCategory(category)
+	}
+	return &proto.ListCategoriesResponse{Categories: protoCategories}, nil
+}
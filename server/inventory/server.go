@@ -0,0 +1,313 @@
+package inventory
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/infrastructure/metrics"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/usecase"
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError maps domain errors to gRPC status codes so callers can tell
+// client mistakes apart from server failures.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrProductNotFound), errors.Is(err, domain.ErrCategoryNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidProduct), errors.Is(err, domain.ErrInvalidUnit),
+		errors.Is(err, domain.ErrInvalidCurrency), errors.Is(err, domain.ErrInvalidCategory),
+		errors.Is(err, domain.ErrEmptySearch), errors.Is(err, domain.ErrInvalidSort),
+		errors.Is(err, domain.ErrInvalidCursor), errors.Is(err, domain.ErrInvalidPriceFilter),
+		errors.Is(err, domain.ErrInvalidLabel), errors.Is(err, domain.ErrInvalidStockChange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrCategoryExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrCategoryInUse), errors.Is(err, domain.ErrCategoryCycle),
+		errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrProductArchived):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
+type inventoryServer struct {
+	proto.UnimplementedInventoryServiceServer
+	uc         *usecase.ProductUseCase
+	categories *usecase.CategoryUseCase
+}
+
+func NewInventoryServer(uc *usecase.ProductUseCase, categories *usecase.CategoryUseCase) *inventoryServer {
+	return &inventoryServer{uc: uc, categories: categories}
+}
+
+func (s *inventoryServer) CreateProduct(ctx context.Context, req *proto.CreateProductRequest) (*proto.CreateProductResponse, error) {
+	product := domain.Product{
+		Name:        req.Name,
+		Price:       domain.MoneyFromFields(req.PriceAmount, req.Currency, req.Price),
+		Stock:       int(req.Stock),
+		Description: req.Description,
+		ImageURL:    req.ImageUrl,
+		Unit:        req.Unit,
+		CategoryID:  req.CategoryId,
+		Tags:        req.Tags,
+		Allergens:   req.Allergens,
+	}
+	product, err := s.uc.Create(product)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.CreateProductResponse{Id: product.ID}, nil
+}
+
+func (s *inventoryServer) GetProduct(ctx context.Context, req *proto.GetProductRequest) (*proto.GetProductResponse, error) {
+	product, err := s.uc.GetByID(req.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return productResponse(product), nil
+}
+
+func (s *inventoryServer) GetProducts(ctx context.Context, req *proto.GetProductsRequest) (*proto.GetProductsResponse, error) {
+	products, err := s.uc.GetByIDs(req.Ids)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := &proto.GetProductsResponse{Products: make([]*proto.GetProductResponse, len(products))}
+	for i, product := range products {
+		resp.Products[i] = productResponse(product)
+	}
+	return resp, nil
+}
+
+func productResponse(product domain.Product) *proto.GetProductResponse {
+	return &proto.GetProductResponse{
+		Id:          product.ID,
+		Name:        product.Name,
+		Price:       product.Price.Float64(),
+		Stock:       int32(product.Stock),
+		PriceAmount: product.Price.Amount,
+		Currency:    product.Price.Currency,
+		ArchivedAt:  archivedAt(product),
+		Description: product.Description,
+		ImageUrl:    product.ImageURL,
+		Unit:        product.Unit,
+		CategoryId:  product.CategoryID,
+		Tags:        product.Tags,
+		Allergens:   product.Allergens,
+	}
+}
+
+// archivedAt returns when the product was archived as a Unix timestamp, or 0.
+func archivedAt(product domain.Product) int64 {
+	if product.ArchivedAt == nil {
+		return 0
+	}
+	return product.ArchivedAt.Unix()
+}
+
+func (s *inventoryServer) UpdateProduct(ctx context.Context, req *proto.UpdateProductRequest) (*proto.UpdateProductResponse, error) {
+	product := domain.Product{
+		Name:        req.Name,
+		Price:       domain.MoneyFromFields(req.PriceAmount, req.Currency, req.Price),
+		Stock:       int(req.Stock),
+		Description: req.Description,
+		ImageURL:    req.ImageUrl,
+		Unit:        req.Unit,
+		CategoryID:  req.CategoryId,
+		Tags:        req.Tags,
+		Allergens:   req.Allergens,
+	}
+	product, err := s.uc.Update(req.Id, product)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.UpdateProductResponse{
+		Id:          product.ID,
+		Name:        product.Name,
+		Price:       product.Price.Float64(),
+		Stock:       int32(product.Stock),
+		PriceAmount: product.Price.Amount,
+		Currency:    product.Price.Currency,
+		Description: product.Description,
+		ImageUrl:    product.ImageURL,
+		Unit:        product.Unit,
+		CategoryId:  product.CategoryID,
+		Tags:        product.Tags,
+		Allergens:   product.Allergens,
+	}, nil
+}
+
+func (s *inventoryServer) DeleteProduct(ctx context.Context, req *proto.DeleteProductRequest) (*proto.DeleteProductResponse, error) {
+	err := s.uc.Delete(req.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.DeleteProductResponse{Success: true}, nil
+}
+
+func (s *inventoryServer) RestoreProduct(ctx context.Context, req *proto.RestoreProductRequest) (*proto.RestoreProductResponse, error) {
+	err := s.uc.Restore(req.Id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.RestoreProductResponse{Success: true}, nil
+}
+
+// filterFromProto tolerates a nil filter, which lists everything.
+func filterFromProto(f *proto.FilterParams) (domain.FilterParams, error) {
+	minPrice, err := domain.PriceBoundFromFields(f.GetMinPriceAmount(), f.GetCurrency(), f.GetMinPrice())
+	if err != nil {
+		return domain.FilterParams{}, err
+	}
+	maxPrice, err := domain.PriceBoundFromFields(f.GetMaxPriceAmount(), f.GetCurrency(), f.GetMaxPrice())
+	if err != nil {
+		return domain.FilterParams{}, err
+	}
+	return domain.FilterParams{
+		Name:             f.GetName(),
+		MinPrice:         minPrice,
+		MaxPrice:         maxPrice,
+		IncludeArchived:  f.GetIncludeArchived(),
+		CategoryID:       f.GetCategoryId(),
+		Tags:             f.GetTags(),
+		ExcludeAllergens: f.GetExcludeAllergens(),
+		Unit:             f.GetUnit(),
+	}, nil
+}
+
+func paginationFromProto(p *proto.PaginationParams) domain.PaginationParams {
+	return domain.PaginationParams{
+		Page:    int(p.GetPage()),
+		PerPage: int(p.GetPerPage()),
+	}
+}
+
+func toProtoProduct(p domain.Product) *proto.Product {
+	return &proto.Product{
+		Id:          p.ID,
+		Name:        p.Name,
+		Price:       p.Price.Float64(),
+		Stock:       int32(p.Stock),
+		PriceAmount: p.Price.Amount,
+		Currency:    p.Price.Currency,
+		ArchivedAt:  archivedAt(p),
+		Description: p.Description,
+		ImageUrl:    p.ImageURL,
+		Unit:        p.Unit,
+		CategoryId:  p.CategoryID,
+		Tags:        p.Tags,
+		Allergens:   p.Allergens,
+	}
+}
+
+func (s *inventoryServer) ListProducts(ctx context.Context, req *proto.ListProductsRequest) (*proto.ListProductsResponse, error) {
+	pagination := usecase.ProductPagination(paginationFromProto(req.Pagination))
+	sort := domain.SortParams{By: req.SortBy, Desc: strings.EqualFold(req.SortDir, "desc")}
+	filter, err := filterFromProto(req.Filter)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	page, err := s.uc.List(filter, sort, pagination, req.Cursor)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	protoProducts := make([]*proto.Product, len(page.Products))
+	for i, p := range page.Products {
+		protoProducts[i] = toProtoProduct(p)
+	}
+
+	return &proto.ListProductsResponse{
+		Products:   protoProducts,
+		Total:      int32(page.Total),
+		Page:       int32(pagination.Page),
+		PerPage:    int32(pagination.PerPage),
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}, nil
+}
+
+func (s *inventoryServer) SearchProducts(ctx context.Context, req *proto.SearchProductsRequest) (*proto.SearchProductsResponse, error) {
+	pagination := usecase.ProductPagination(paginationFromProto(req.Pagination))
+	filter, err := filterFromProto(req.Filter)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	results, total, err := s.uc.Search(req.Query, filter, pagination)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	protoResults := make([]*proto.SearchResult, len(results))
+	for i, res := range results {
+		protoResults[i] = &proto.SearchResult{
+			Product:       toProtoProduct(res.Product),
+			Rank:          res.Rank,
+			NameHighlight: res.NameHighlight,
+			Snippet:       res.Snippet,
+		}
+	}
+
+	return &proto.SearchProductsResponse{
+		Results: protoResults,
+		Total:   int32(total),
+		Page:    int32(pagination.Page),
+		PerPage: int32(pagination.PerPage),
+	}, nil
+}
+
+func (s *inventoryServer) UpdateStock(ctx context.Context, req *proto.UpdateStockRequest) (*proto.UpdateStockResponse, error) {
+	if err := s.uc.AdjustStock(req.Id, int(req.Stock), req.Decrement); err != nil {
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			metrics.StockOut()
+		}
+		return nil, statusError(err)
+	}
+
+	return &proto.UpdateStockResponse{Success: true}, nil
+}
+
+func (s *inventoryServer) ReserveStock(ctx context.Context, req *proto.ReserveStockRequest) (*proto.ReserveStockResponse, error) {
+	lines := make([]domain.StockLine, len(req.Items))
+	for i, item := range req.Items {
+		lines[i] = domain.StockLine{
+			ProductID: item.ProductId,
+			Quantity:  int(item.Quantity),
+		}
+	}
+
+	if err := s.uc.ReserveStock(req.Reference, lines); err != nil {
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			metrics.StockOut()
+		}
+		return nil, statusError(err)
+	}
+
+	return &proto.ReserveStockResponse{Success: true}, nil
+}
+
+func (s *inventoryServer) ReleaseStock(ctx context.Context, req *proto.ReleaseStockRequest) (*proto.ReleaseStockResponse, error) {
+	released, err := s.uc.ReleaseStock(req.Reference)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &proto.ReleaseStockResponse{Success: true, Released: released}, nil
+}
+
+func (s *inventoryServer) ListTags(ctx context.Context, req *proto.ListTagsRequest) (*proto.ListTagsResponse, error) {
+	tags, err := s.uc.ListTags()
+	if err != nil {
+		return nil, err
+	}
+
+	protoTags := make([]*proto.TagCount, len(tags))
+	for i, tag := range tags {
+		protoTags[i] = &proto.TagCount{Tag: tag.Tag, Count: int32(tag.Count)}
+	}
+	return &proto.ListTagsResponse{Tags: protoTags}, nil
+}
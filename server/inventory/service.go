@@ -0,0 +1,49 @@
+package inventory
+
+import (
+	"FoodStore-AdvProg2/infrastructure/config"
+	"FoodStore-AdvProg2/infrastructure/postgres"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/server"
+	"FoodStore-AdvProg2/usecase"
+	"context"
+
+	grpcpkg "google.golang.org/grpc"
+)
+
+// Service is the inventory service's product and category gRPC server.
+type Service struct {
+	products   *usecase.ProductUseCase
+	categories *usecase.CategoryUseCase
+}
+
+// New builds the inventory service on postgres.DB.
+func New() *Service {
+	categoryRepo := postgres.NewCategoryPostgresRepo()
+	return &Service{
+		products:   usecase.NewProductUseCase(postgres.NewProductPostgresRepo(), categoryRepo),
+		categories: usecase.NewCategoryUseCase(categoryRepo),
+	}
+}
+
+func (s *Service) Register(grpcServer *grpcpkg.Server) {
+	proto.RegisterInventoryServiceServer(grpcServer, NewInventoryServer(s.products, s.categories))
+}
+
+// Serve runs the inventory service on its own database and port until ctx is
+// cancelled.
+func Serve(ctx context.Context, cfg *config.Config) error {
+	if err := server.OpenDatabase(cfg, postgres.ServiceInventory); err != nil {
+		return err
+	}
+	defer server.CloseDatabase()
+
+	grpcServer := server.NewGRPCServer()
+	New().Register(grpcServer)
+	health := server.NewHealth(grpcServer)
+	health.AddCheck("postgres", server.PingDatabase)
+	go health.Run(ctx)
+	go server.ServeMetrics(ctx, cfg.Inventory.MetricsPort)
+
+	return server.ServeGRPC(ctx, cfg, grpcServer, cfg.Inventory.Port, "Inventory Service")
+}
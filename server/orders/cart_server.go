@@ -0,0 +1,108 @@
+package orders
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/infrastructure/metrics"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/usecase"
+	"context"
+	"errors"
+)
+
+type cartServer struct {
+	proto.UnimplementedCartServiceServer
+	uc *usecase.CartUseCase
+}
+
+func NewCartServer(uc *usecase.CartUseCase) *cartServer {
+	return &cartServer{uc: uc}
+}
+
+func toProtoCart(cart domain.Cart) *proto.Cart {
+	items := make([]*proto.CartItem, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = &proto.CartItem{
+			ProductId:          item.ProductID,
+			Name:               item.Name,
+			Quantity:           int32(item.Quantity),
+			Price:              item.Price.Float64(),
+			CurrentPrice:       item.CurrentPrice.Float64(),
+			Stock:              int32(item.Stock),
+			Problem:            item.Problem,
+			AddedAt:            item.AddedAt.Unix(),
+			PriceAmount:        item.Price.Amount,
+			CurrentPriceAmount: item.CurrentPrice.Amount,
+			Currency:           item.Price.Currency,
+		}
+	}
+
+	return &proto.Cart{
+		UserId:      cart.UserID,
+		Items:       items,
+		TotalPrice:  cart.TotalPrice.Float64(),
+		TotalAmount: cart.TotalPrice.Amount,
+		Currency:    cart.TotalPrice.Currency,
+	}
+}
+
+func (s *cartServer) GetCart(ctx context.Context, req *proto.GetCartRequest) (*proto.Cart, error) {
+	cart, err := s.uc.GetCart(req.UserId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoCart(cart), nil
+}
+
+func (s *cartServer) AddCartItem(ctx context.Context, req *proto.AddCartItemRequest) (*proto.Cart, error) {
+	cart, err := s.uc.AddItem(req.UserId, req.ProductId, int(req.Quantity))
+	if err != nil {
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			metrics.StockOut()
+		}
+		return nil, statusError(err)
+	}
+	return toProtoCart(cart), nil
+}
+
+func (s *cartServer) UpdateCartItem(ctx context.Context, req *proto.UpdateCartItemRequest) (*proto.Cart, error) {
+	cart, err := s.uc.UpdateItem(req.UserId, req.ProductId, int(req.Quantity))
+	if err != nil {
+		if errors.Is(err, domain.ErrInsufficientStock) {
+			metrics.StockOut()
+		}
+		return nil, statusError(err)
+	}
+	return toProtoCart(cart), nil
+}
+
+func (s *cartServer) RemoveCartItem(ctx context.Context, req *proto.RemoveCartItemRequest) (*proto.Cart, error) {
+	cart, err := s.uc.RemoveItem(req.UserId, req.ProductId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoCart(cart), nil
+}
+
+func (s *cartServer) ClearCart(ctx context.Context, req *proto.ClearCartRequest) (*proto.Cart, error) {
+	if err := s.uc.Clear(req.UserId); err != nil {
+		return nil, statusError(err)
+	}
+	return toProtoCart(domain.Cart{UserID: req.UserId}), nil
+}
+
+func (s *cartServer) CheckoutCart(ctx context.Context, req *proto.CheckoutCartRequest) (*proto.CheckoutCartResponse, error) {
+	orderID, cart, err := s.uc.Checkout(req.UserId, req.IdempotencyKey)
+	if err != nil {
+		// Lines that no longer fit the stock are caught here, before any
+		// reservation reaches inventory.
+		if errors.Is(err, domain.ErrCartChanged) {
+			for _, item := range cart.Items {
+				if item.Problem == domain.CartProblemInsufficientStock {
+					metrics.StockOut()
+				}
+			}
+		}
+		return nil, statusError(err)
+	}
+	return &proto.CheckoutCartResponse{OrderId: orderID}, nil
+}
@@ -0,0 +1,201 @@
+package orders
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/infrastructure/grpc"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/usecase"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusError maps domain errors to gRPC status codes so callers can tell
+// client mistakes apart from server failures.
+func statusError(err error) error {
+	var transitionErr *domain.InvalidTransitionError
+	switch {
+	case errors.As(err, &transitionErr):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrUnknownOrderStatus), errors.Is(err, domain.ErrStatusNotSettable), errors.Is(err, domain.ErrInvalidSort),
+		errors.Is(err, domain.ErrInvalidCursor), errors.Is(err, domain.ErrInvalidCurrency),
+		errors.Is(err, domain.ErrIdempotencyKeyTooLong), errors.Is(err, domain.ErrMixedCurrencies):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrOrderStatusChanged), errors.Is(err, domain.ErrIdempotencyKeyInProgress):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrCartItemNotFound), errors.Is(err, domain.ErrProductNotFound), errors.Is(err, domain.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidQuantity):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrCartEmpty), errors.Is(err, domain.ErrCartChanged), errors.Is(err, domain.ErrInsufficientStock),
+		errors.Is(err, domain.ErrCurrencyMismatch), errors.Is(err, domain.ErrProductArchived):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
+type orderServer struct {
+	proto.UnimplementedOrderServiceServer
+	uc     *usecase.OrderUseCase
+	events *grpc.OrderEventBroadcaster
+}
+
+func NewOrderServer(uc *usecase.OrderUseCase, events *grpc.OrderEventBroadcaster) *orderServer {
+	return &orderServer{uc: uc, events: events}
+}
+
+func (s *orderServer) CreateOrder(ctx context.Context, req *proto.CreateOrderRequest) (*proto.CreateOrderResponse, error) {
+	items := make([]domain.OrderItemRequest, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = domain.OrderItemRequest{
+			ProductID: item.ProductId,
+			Quantity:  int(item.Quantity),
+		}
+	}
+
+	orderReq := domain.OrderRequest{
+		UserID:         req.UserId,
+		Items:          items,
+		IdempotencyKey: req.IdempotencyKey,
+	}
+
+	orderID, err := s.uc.CreateOrder(orderReq)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &proto.CreateOrderResponse{OrderId: orderID}, nil
+}
+
+func (s *orderServer) GetOrder(ctx context.Context, req *proto.GetOrderRequest) (*proto.OrderResponse, error) {
+	order, err := s.uc.GetOrderByID(req.OrderId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toProtoOrder(order), nil
+}
+
+func toProtoOrder(order domain.Order) *proto.OrderResponse {
+	items := make([]*proto.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &proto.OrderItem{
+			Id:          item.ID,
+			OrderId:     item.OrderID,
+			ProductId:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    int32(item.Quantity),
+			Price:       item.Price.Float64(),
+			PriceAmount: item.Price.Amount,
+			Currency:    item.Price.Currency,
+		}
+	}
+
+	return &proto.OrderResponse{
+		Id:          order.ID,
+		UserId:      order.UserID,
+		TotalPrice:  order.TotalPrice.Float64(),
+		Status:      order.Status,
+		CreatedAt:   order.CreatedAt.Unix(),
+		Items:       items,
+		TotalAmount: order.TotalPrice.Amount,
+		Currency:    order.TotalPrice.Currency,
+	}
+}
+
+func (s *orderServer) UpdateOrderStatus(ctx context.Context, req *proto.UpdateOrderStatusRequest) (*proto.UpdateOrderStatusResponse, error) {
+	err := s.uc.UpdateOrderStatus(req.OrderId, req.Status, req.ActorId, req.Reason)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &proto.UpdateOrderStatusResponse{Status: "updated"}, nil
+}
+
+func (s *orderServer) ListOrders(ctx context.Context, req *proto.ListOrdersRequest) (*proto.ListOrdersResponse, error) {
+	f := req.GetFilter()
+	currency := domain.NormalizeCurrency(f.GetCurrency())
+	filter := domain.OrderFilter{
+		UserID:   f.GetUserId(),
+		Statuses: f.GetStatuses(),
+		MinTotal: domain.Money{Amount: f.GetMinTotalAmount(), Currency: currency},
+		MaxTotal: domain.Money{Amount: f.GetMaxTotalAmount(), Currency: currency},
+	}
+	if f.GetCreatedFrom() != 0 {
+		filter.CreatedFrom = time.Unix(f.GetCreatedFrom(), 0)
+	}
+	if f.GetCreatedTo() != 0 {
+		filter.CreatedTo = time.Unix(f.GetCreatedTo(), 0)
+	}
+	sort := domain.SortParams{By: req.SortBy, Desc: !strings.EqualFold(req.SortDir, "asc")}
+	pagination := domain.PaginationParams{Page: int(req.Page), PerPage: int(req.PerPage)}
+
+	page, err := s.uc.ListOrders(filter, sort, pagination, req.Cursor, req.IncludeItems)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	orders := make([]*proto.OrderResponse, len(page.Orders))
+	for i, order := range page.Orders {
+		orders[i] = toProtoOrder(order)
+	}
+
+	return &proto.ListOrdersResponse{
+		Orders:     orders,
+		Total:      int32(page.Total),
+		Page:       int32(page.Page),
+		PerPage:    int32(page.PerPage),
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}, nil
+}
+
+func (s *orderServer) GetOrderHistory(ctx context.Context, req *proto.GetOrderHistoryRequest) (*proto.GetOrderHistoryResponse, error) {
+	history, err := s.uc.GetOrderHistory(req.OrderId)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	changes := make([]*proto.OrderStatusChange, len(history))
+	for i, change := range history {
+		changes[i] = &proto.OrderStatusChange{
+			Id:        change.ID,
+			OrderId:   change.OrderID,
+			OldStatus: change.OldStatus,
+			NewStatus: change.NewStatus,
+			ActorId:   change.ActorID,
+			Reason:    change.Reason,
+			ChangedAt: change.ChangedAt.Unix(),
+		}
+	}
+
+	return &proto.GetOrderHistoryResponse{Changes: changes}, nil
+}
+
+func (s *orderServer) SubscribeOrderEvents(req *proto.SubscribeOrderEventsRequest, stream proto.OrderService_SubscribeOrderEventsServer) error {
+	events, unsubscribe := s.events.Subscribe(req.EventTypes)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "order service is shutting down")
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
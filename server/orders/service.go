@@ -0,0 +1,121 @@
+package orders
+
+import (
+	"FoodStore-AdvProg2/infrastructure/config"
+	"FoodStore-AdvProg2/infrastructure/grpc"
+	"FoodStore-AdvProg2/infrastructure/metrics"
+	"FoodStore-AdvProg2/infrastructure/postgres"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/server"
+	"FoodStore-AdvProg2/usecase"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	grpcpkg "google.golang.org/grpc"
+)
+
+// Service is the order service: the order and cart gRPC servers and the
+// background work they depend on.
+type Service struct {
+	orders *usecase.OrderUseCase
+	carts  *usecase.CartUseCase
+	events *grpc.OrderEventBroadcaster
+	relay  *usecase.OutboxRelay
+
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
+}
+
+// New builds the order service on postgres.DB. Users and products are
+// checked through the given clients.
+func New(cfg *config.Config, userClient proto.UserServiceClient, productClient proto.InventoryServiceClient) *Service {
+	uc := usecase.NewOrderUseCase(postgres.NewOrderPostgresRepo(), postgres.NewSagaPostgresRepo(),
+		postgres.NewIdempotencyPostgresRepo(), productClient, userClient)
+	events := grpc.NewOrderEventBroadcaster(256)
+	return &Service{
+		orders: uc,
+		carts:  usecase.NewCartUseCase(postgres.NewCartPostgresRepo(), productClient, uc),
+		events: events,
+		relay:  usecase.NewOutboxRelay(postgres.NewOutboxPostgresRepo(), cfg.Orders.OutboxInterval.Duration, events, metrics.OrderEvents),
+	}
+}
+
+func (s *Service) Register(grpcServer *grpcpkg.Server) {
+	proto.RegisterOrderServiceServer(grpcServer, NewOrderServer(s.orders, s.events))
+	proto.RegisterCartServiceServer(grpcServer, NewCartServer(s.carts))
+}
+
+// Start starts recovering abandoned order sagas and the outbox relay. The
+// user and inventory services must be reachable.
+func (s *Service) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopWorkers = cancel
+	s.workers.Add(2)
+	go func() {
+		defer s.workers.Done()
+		s.orders.RunSagaRecovery(ctx)
+	}()
+	go func() {
+		defer s.workers.Done()
+		s.relay.Run(ctx)
+	}()
+}
+
+// EndStreams ends every SubscribeOrderEvents stream, which would otherwise
+// keep a graceful stop waiting until its deadline.
+func (s *Service) EndStreams() {
+	s.events.Close()
+}
+
+// Stop stops saga recovery and the outbox relay once they have finished the
+// saga or batch in flight, or after timeout. Undelivered events stay in the
+// outbox for the next start.
+func (s *Service) Stop(timeout time.Duration) {
+	if s.stopWorkers == nil {
+		return
+	}
+	s.stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Printf("Saga recovery or outbox relay still running after %s, not waiting for them", timeout)
+	}
+}
+
+// Serve runs the order service on its own database and port, calling the
+// user and inventory services at their configured URLs, until ctx is
+// cancelled.
+func Serve(ctx context.Context, cfg *config.Config) error {
+	if err := server.OpenDatabase(cfg, postgres.ServiceOrders); err != nil {
+		return err
+	}
+	defer server.CloseDatabase()
+
+	userClient, userConn := grpc.NewUserClient(cfg.Users.URL)
+	defer userConn.Close()
+	productClient, productConn := grpc.NewProductClient(cfg.Inventory.URL)
+	defer productConn.Close()
+
+	service := New(cfg, userClient, productClient)
+	service.Start()
+	defer service.Stop(cfg.ShutdownTimeout.Duration)
+	context.AfterFunc(ctx, service.EndStreams)
+
+	grpcServer := server.NewGRPCServer()
+	service.Register(grpcServer)
+	health := server.NewHealth(grpcServer)
+	health.AddCheck("postgres", server.PingDatabase)
+	health.AddCheck("users", server.ServiceHealth(userConn))
+	health.AddCheck("inventory", server.ServiceHealth(productConn))
+	go health.Run(ctx)
+	go server.ServeMetrics(ctx, cfg.Orders.MetricsPort)
+
+	return server.ServeGRPC(ctx, cfg, grpcServer, cfg.Orders.Port, "Order Service")
+}
@@ -0,0 +1,155 @@
+// Package server holds the startup and shutdown steps the service packages
+// share: opening a service's database, serving gRPC on its port and stopping
+// it gracefully on SIGINT or SIGTERM.
+package server
+
+import (
+	"FoodStore-AdvProg2/infrastructure/config"
+	"FoodStore-AdvProg2/infrastructure/metrics"
+	"FoodStore-AdvProg2/infrastructure/postgres"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// OpenDatabase connects postgres.DB to the service's own database and applies
+// the service's pending migrations.
+func OpenDatabase(cfg *config.Config, service string) error {
+	url, err := cfg.DatabaseURL(service)
+	if err != nil {
+		return err
+	}
+	if err := connect(url); err != nil {
+		return err
+	}
+	return postgres.RunMigrations(service)
+}
+
+// OpenSharedDatabase connects postgres.DB to the database of every service,
+// which must be the same one, and applies all their pending migrations. It
+// is used when the services run in one process and share one pool.
+func OpenSharedDatabase(cfg *config.Config) error {
+	var url string
+	for _, service := range postgres.Services {
+		serviceURL, err := cfg.DatabaseURL(service)
+		if err != nil {
+			return err
+		}
+		if url != "" && serviceURL != url {
+			return fmt.Errorf("services in one process share one database pool, but %s is configured with a different database; set DB instead", service)
+		}
+		url = serviceURL
+	}
+	if err := connect(url); err != nil {
+		return err
+	}
+	for _, service := range postgres.Services {
+		if err := postgres.RunMigrations(service); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func connect(url string) error {
+	db, err := postgres.InitDB(url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	postgres.DB = db
+	metrics.RegisterPool(db)
+	log.Println("Connected to PostgreSQL via pgxpool")
+	return nil
+}
+
+// SignalContext returns a context that is cancelled by SIGINT or SIGTERM.
+// The first signal starts a graceful shutdown; default handling is then
+// restored, so a second signal kills the process without waiting.
+func SignalContext() context.Context {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	context.AfterFunc(ctx, stop)
+	return ctx
+}
+
+// CloseDatabase closes postgres.DB once nothing uses it any more.
+func CloseDatabase() {
+	if postgres.DB != nil {
+		postgres.DB.Close()
+		log.Println("Database pool closed")
+	}
+}
+
+// NewGRPCServer returns a gRPC server that records per-method metrics.
+func NewGRPCServer() *grpc.Server {
+	return grpc.NewServer(
+		grpc.ChainUnaryInterceptor(metrics.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(metrics.StreamServerInterceptor))
+}
+
+// ServeMetrics serves /metrics on port until ctx is cancelled. A port of 0
+// disables it. Failures are logged rather than stopping the service.
+func ServeMetrics(ctx context.Context, port int) {
+	if port == 0 {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	srv := &http.Server{Addr: config.ListenAddr(port), Handler: mux}
+	context.AfterFunc(ctx, func() { srv.Close() })
+
+	log.Printf("Serving metrics on %s/metrics", srv.Addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("Metrics server failed: %v", err)
+	}
+}
+
+// ServeGRPC serves s on port until it fails or ctx is cancelled, in which
+// case it stops s gracefully.
+func ServeGRPC(ctx context.Context, cfg *config.Config, s *grpc.Server, port int, name string) error {
+	addr := config.ListenAddr(port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	serveErr := make(chan error, 1)
+	log.Printf("Starting gRPC %s on %s...", name, addr)
+	go func() { serveErr <- s.Serve(listener) }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	log.Printf("Shutting down gRPC %s...", name)
+	GracefulStop(s, cfg.ShutdownTimeout.Duration)
+	return nil
+}
+
+// GracefulStop stops s from accepting RPCs and waits for the ones in flight.
+// RPCs still running after timeout are cancelled.
+func GracefulStop(s *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		log.Printf("RPCs still running after %s, cancelling them", timeout)
+		s.Stop()
+		<-stopped
+	}
+}
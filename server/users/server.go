@@ -0,0 +1,179 @@
+package users
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/infrastructure/auth"
+	"FoodStore-AdvProg2/infrastructure/config"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/usecase"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type userServer struct {
+	proto.UnimplementedUserServiceServer
+	uc *usecase.UserUseCase
+}
+
+func NewUserServer(uc *usecase.UserUseCase) *userServer {
+	return &userServer{uc: uc}
+}
+
+func (s *userServer) Register(ctx context.Context, req *proto.RegisterRequest) (*proto.RegisterResponse, error) {
+	user := domain.User{
+		Username: req.Username,
+		Password: req.Password,
+		Email:    req.Email,
+	}
+
+	userID, err := s.uc.Register(user)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RegisterResponse{UserId: userID}, nil
+}
+
+func (s *userServer) Authenticate(ctx context.Context, req *proto.AuthenticateRequest) (*proto.AuthenticateResponse, error) {
+	session, err := s.uc.Authenticate(req.Username, req.Password)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return &proto.AuthenticateResponse{
+		UserId:           session.UserID,
+		Token:            session.AccessToken,
+		ExpiresAt:        session.AccessExpiresAt.Unix(),
+		RefreshToken:     session.RefreshToken,
+		RefreshExpiresAt: session.RefreshExpiresAt.Unix(),
+	}, nil
+}
+
+func (s *userServer) RefreshToken(ctx context.Context, req *proto.RefreshTokenRequest) (*proto.RefreshTokenResponse, error) {
+	session, err := s.uc.Refresh(req.RefreshToken)
+	if errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrTokenExpired) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RefreshTokenResponse{
+		UserId:           session.UserID,
+		Token:            session.AccessToken,
+		ExpiresAt:        session.AccessExpiresAt.Unix(),
+		RefreshToken:     session.RefreshToken,
+		RefreshExpiresAt: session.RefreshExpiresAt.Unix(),
+	}, nil
+}
+
+func (s *userServer) GetSigningKeys(ctx context.Context, req *proto.GetSigningKeysRequest) (*proto.GetSigningKeysResponse, error) {
+	keys := s.uc.SigningKeys()
+	resp := &proto.GetSigningKeysResponse{Keys: make([]*proto.SigningKey, len(keys))}
+	for i, key := range keys {
+		resp.Keys[i] = &proto.SigningKey{
+			Kty: "OKP",
+			Kid: key.KeyID,
+			Alg: key.Algorithm,
+			Use: "sig",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key.PublicKey),
+		}
+	}
+	return resp, nil
+}
+
+func (s *userServer) GetProfile(ctx context.Context, req *proto.GetProfileRequest) (*proto.GetProfileResponse, error) {
+	user, err := s.uc.GetProfile(req.UserId)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetProfileResponse{
+		UserId:   user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		Role:     user.Role,
+	}, nil
+}
+
+func (s *userServer) ValidateToken(ctx context.Context, req *proto.ValidateTokenRequest) (*proto.ValidateTokenResponse, error) {
+	user, err := s.uc.ValidateToken(req.Token)
+	if errors.Is(err, domain.ErrInvalidToken) || errors.Is(err, domain.ErrTokenExpired) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ValidateTokenResponse{UserId: user.ID, Role: user.Role}, nil
+}
+
+func (s *userServer) SetUserRole(ctx context.Context, req *proto.SetUserRoleRequest) (*proto.SetUserRoleResponse, error) {
+	err := s.uc.SetRole(req.UserId, req.Role)
+	if errors.Is(err, domain.ErrInvalidRole) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.SetUserRoleResponse{UserId: req.UserId, Role: req.Role}, nil
+}
+
+func (s *userServer) RevokeToken(ctx context.Context, req *proto.RevokeTokenRequest) (*proto.RevokeTokenResponse, error) {
+	err := s.uc.RevokeToken(req.UserId, req.Token)
+	if errors.Is(err, domain.ErrInvalidToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RevokeTokenResponse{Success: true}, nil
+}
+
+func (s *userServer) RevokeAllTokens(ctx context.Context, req *proto.RevokeAllTokensRequest) (*proto.RevokeAllTokensResponse, error) {
+	revoked, err := s.uc.RevokeAllTokens(req.UserId)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RevokeAllTokensResponse{Revoked: revoked}, nil
+}
+
+// newSigner builds the access token signer from the configured algorithm and
+// its key: a base64 Ed25519 seed for EdDSA, the shared secret for HS256.
+func newSigner(cfg config.JWTConfig) *auth.Signer {
+	switch alg := cfg.SigningAlg; alg {
+	case "", auth.AlgorithmEdDSA:
+		encoded := cfg.PrivateKey
+		if encoded == "" {
+			_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+			if err != nil {
+				log.Fatalf("Failed to generate signing key: %v", err)
+			}
+			log.Printf("Warning: JWT_PRIVATE_KEY not set, using a temporary signing key; access tokens will not survive a restart")
+			return auth.NewEd25519Signer(privateKey)
+		}
+		seed, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			log.Fatalf("Invalid JWT_PRIVATE_KEY: must be a base64-encoded %d-byte Ed25519 seed", ed25519.SeedSize)
+		}
+		return auth.NewEd25519Signer(ed25519.NewKeyFromSeed(seed))
+	case auth.AlgorithmHS256:
+		signer, err := auth.NewHMACSigner([]byte(cfg.Secret))
+		if err != nil {
+			log.Fatalf("Invalid JWT_SECRET: %v", err)
+		}
+		return signer
+	default:
+		log.Fatalf("Unsupported JWT_SIGNING_ALG %q", alg)
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package users
+
+import (
+	"FoodStore-AdvProg2/infrastructure/config"
+	"FoodStore-AdvProg2/infrastructure/postgres"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/server"
+	"FoodStore-AdvProg2/usecase"
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Service is the user service's gRPC server and its expired token sweeper.
+type Service struct {
+	uc            *usecase.UserUseCase
+	sweepInterval time.Duration
+
+	stopSweeper context.CancelFunc
+	sweeperDone chan struct{}
+}
+
+// New builds the user service on postgres.DB.
+func New(cfg *config.Config) *Service {
+	uc := usecase.NewUserUseCase(postgres.NewUserPostgresRepo(postgres.DB), newSigner(cfg.JWT),
+		cfg.Users.AccessTokenTTL.Duration, cfg.Users.RefreshTokenTTL.Duration)
+	return &Service{uc: uc, sweepInterval: cfg.Users.TokenSweepInterval.Duration}
+}
+
+func (s *Service) Register(grpcServer *grpc.Server) {
+	proto.RegisterUserServiceServer(grpcServer, NewUserServer(s.uc))
+}
+
+// Start starts sweeping expired tokens.
+func (s *Service) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopSweeper = cancel
+	s.sweeperDone = make(chan struct{})
+	go func() {
+		defer close(s.sweeperDone)
+		s.uc.RunTokenSweeper(ctx, s.sweepInterval)
+	}()
+}
+
+// Stop stops the token sweeper, waiting up to timeout for a sweep in
+// progress.
+func (s *Service) Stop(timeout time.Duration) {
+	if s.stopSweeper == nil {
+		return
+	}
+	s.stopSweeper()
+	select {
+	case <-s.sweeperDone:
+	case <-time.After(timeout):
+		log.Printf("Token sweeper still running after %s, not waiting for it", timeout)
+	}
+}
+
+// Serve runs the user service on its own database and port until ctx is
+// cancelled.
+func Serve(ctx context.Context, cfg *config.Config) error {
+	if err := server.OpenDatabase(cfg, postgres.ServiceUsers); err != nil {
+		return err
+	}
+	defer server.CloseDatabase()
+
+	service := New(cfg)
+	service.Start()
+	defer service.Stop(cfg.ShutdownTimeout.Duration)
+
+	grpcServer := server.NewGRPCServer()
+	service.Register(grpcServer)
+	health := server.NewHealth(grpcServer)
+	health.AddCheck("postgres", server.PingDatabase)
+	go health.Run(ctx)
+	go server.ServeMetrics(ctx, cfg.Users.MetricsPort)
+
+	return server.ServeGRPC(ctx, cfg, grpcServer, cfg.Users.Port, "User Service")
+}
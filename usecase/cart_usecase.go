@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/repository"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CartUseCase struct {
+	cartRepo      repository.CartRepository
+	productClient proto.InventoryServiceClient
+	orders        *OrderUseCase
+}
+
+func NewCartUseCase(cartRepo repository.CartRepository, productClient proto.InventoryServiceClient, orders *OrderUseCase) *CartUseCase {
+	return &CartUseCase{
+		cartRepo:      cartRepo,
+		productClient: productClient,
+		orders:        orders,
+	}
+}
+
+// GetCart returns the user's cart with every line checked against the
+// current price and stock in inventory.
+func (uc *CartUseCase) GetCart(userID string) (domain.Cart, error) {
+	cart, _, err := uc.loadCart(userID)
+	return cart, err
+}
+
+// loadCart is GetCart that also returns the products of the cart's lines,
+// read from inventory in one call.
+func (uc *CartUseCase) loadCart(userID string) (domain.Cart, map[string]*proto.GetProductResponse, error) {
+	items, err := uc.cartRepo.FindItems(userID)
+	if err != nil {
+		return domain.Cart{}, nil, err
+	}
+
+	cart := domain.Cart{UserID: userID, Items: make([]domain.CartItem, 0, len(items))}
+	if len(items) == 0 {
+		return cart, nil, nil
+	}
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ProductID
+	}
+	products, err := loadProducts(uc.productClient, ids)
+	if err != nil {
+		return domain.Cart{}, nil, err
+	}
+
+	for _, item := range items {
+		item := checkItem(item, products[item.ProductID])
+		cart.Items = append(cart.Items, item)
+		if item.Problem == domain.CartProblemUnavailable {
+			continue
+		}
+		cart.TotalPrice, err = cart.TotalPrice.Add(item.CurrentPrice.Multiply(item.Quantity))
+		if err != nil {
+			return domain.Cart{}, nil, err
+		}
+	}
+	return cart, products, nil
+}
+
+// checkItem fills in the live product details of a cart line and records
+// what, if anything, stops it from being ordered as it is. product is nil
+// when the product no longer exists.
+func checkItem(item domain.CartItem, product *proto.GetProductResponse) domain.CartItem {
+	if product == nil || product.ArchivedAt != 0 {
+		item.Problem = domain.CartProblemUnavailable
+		return item
+	}
+
+	item.Name = product.Name
+	item.CurrentPrice = domain.MoneyFromFields(product.PriceAmount, product.Currency, product.Price)
+	item.Stock = int(product.Stock)
+	switch {
+	case item.Quantity > item.Stock:
+		item.Problem = domain.CartProblemInsufficientStock
+	case item.Price != item.CurrentPrice:
+		item.Problem = domain.CartProblemPriceChanged
+	}
+	return item
+}
+
+func (uc *CartUseCase) product(productID string) (*proto.GetProductResponse, error) {
+	product, err := uc.productClient.GetProduct(context.Background(), &proto.GetProductRequest{Id: productID})
+	if status.Code(err) == codes.NotFound {
+		return nil, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load product %s: %w", productID, err)
+	}
+	if product.ArchivedAt != 0 {
+		return nil, fmt.Errorf("%w: %s", domain.ErrProductArchived, productID)
+	}
+	return product, nil
+}
+
+// AddItem adds quantity of a product to the cart, on top of any quantity
+// already there.
+func (uc *CartUseCase) AddItem(userID, productID string, quantity int) (domain.Cart, error) {
+	if quantity <= 0 {
+		return domain.Cart{}, domain.ErrInvalidQuantity
+	}
+
+	items, err := uc.cartRepo.FindItems(userID)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+	item := domain.CartItem{ProductID: productID, AddedAt: time.Now()}
+	for _, existing := range items {
+		if existing.ProductID == productID {
+			item = existing
+			break
+		}
+	}
+	item.Quantity += quantity
+
+	if err := uc.saveItem(userID, item); err != nil {
+		return domain.Cart{}, err
+	}
+	return uc.GetCart(userID)
+}
+
+// UpdateItem sets the quantity of a product already in the cart. A quantity
+// of zero removes the line.
+func (uc *CartUseCase) UpdateItem(userID, productID string, quantity int) (domain.Cart, error) {
+	if quantity < 0 {
+		return domain.Cart{}, domain.ErrInvalidQuantity
+	}
+	if quantity == 0 {
+		return uc.RemoveItem(userID, productID)
+	}
+
+	items, err := uc.cartRepo.FindItems(userID)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+	for _, item := range items {
+		if item.ProductID != productID {
+			continue
+		}
+		item.Quantity = quantity
+		if err := uc.saveItem(userID, item); err != nil {
+			return domain.Cart{}, err
+		}
+		return uc.GetCart(userID)
+	}
+	return domain.Cart{}, domain.ErrCartItemNotFound
+}
+
+// saveItem checks the line against inventory and stores it at the current
+// price.
+func (uc *CartUseCase) saveItem(userID string, item domain.CartItem) error {
+	product, err := uc.product(item.ProductID)
+	if err != nil {
+		return err
+	}
+	if item.Quantity > int(product.Stock) {
+		return fmt.Errorf("%w for product %s: %d available", domain.ErrInsufficientStock, item.ProductID, product.Stock)
+	}
+
+	price := domain.MoneyFromFields(product.PriceAmount, product.Currency, product.Price)
+	if err := uc.checkCurrency(userID, item.ProductID, price); err != nil {
+		return err
+	}
+
+	item.Price = price
+	return uc.cartRepo.SaveItem(userID, item)
+}
+
+// checkCurrency keeps a cart in one currency, since it becomes one order.
+func (uc *CartUseCase) checkCurrency(userID, productID string, price domain.Money) error {
+	items, err := uc.cartRepo.FindItems(userID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.ProductID != productID && item.Price.Currency != price.Currency {
+			return fmt.Errorf("%w: the cart is in %s but this product is priced in %s",
+				domain.ErrCurrencyMismatch, item.Price.Currency, price.Currency)
+		}
+	}
+	return nil
+}
+
+func (uc *CartUseCase) RemoveItem(userID, productID string) (domain.Cart, error) {
+	removed, err := uc.cartRepo.RemoveItem(userID, productID)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+	if !removed {
+		return domain.Cart{}, domain.ErrCartItemNotFound
+	}
+	return uc.GetCart(userID)
+}
+
+func (uc *CartUseCase) Clear(userID string) error {
+	return uc.cartRepo.Clear(userID)
+}
+
+// Checkout turns the cart into an order and empties it. If a price changed
+// since the customer last saw it, the cart is updated to the new prices and
+// checkout fails with ErrCartChanged so the customer can review it first;
+// lines that are out of stock or no longer sold must be fixed by the customer.
+//
+// A retry with the idempotency key of a checkout that already placed its
+// order returns that order, even though the cart has been emptied since.
+func (uc *CartUseCase) Checkout(userID, idempotencyKey string) (string, domain.Cart, error) {
+	orderID, err := uc.orders.OrderForIdempotencyKey(userID, idempotencyKey)
+	if err != nil {
+		return "", domain.Cart{}, err
+	}
+	if orderID != "" {
+		cart, err := uc.GetCart(userID)
+		if err != nil {
+			return "", domain.Cart{}, err
+		}
+		return orderID, cart, nil
+	}
+
+	cart, products, err := uc.loadCart(userID)
+	if err != nil {
+		return "", domain.Cart{}, err
+	}
+	if len(cart.Items) == 0 {
+		return "", cart, domain.ErrCartEmpty
+	}
+
+	changed := false
+	for i, item := range cart.Items {
+		switch item.Problem {
+		case "":
+			continue
+		case domain.CartProblemPriceChanged:
+			item.Price = item.CurrentPrice
+			if err := uc.cartRepo.SaveItem(userID, item); err != nil {
+				return "", domain.Cart{}, err
+			}
+			cart.Items[i].Price = item.CurrentPrice
+		}
+		changed = true
+	}
+	if changed {
+		return "", cart, domain.ErrCartChanged
+	}
+
+	items := make([]domain.OrderItemRequest, len(cart.Items))
+	for i, item := range cart.Items {
+		items[i] = domain.OrderItemRequest{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+	orderID, err = uc.orders.placeOrder(domain.OrderRequest{
+		UserID:         userID,
+		Items:          items,
+		IdempotencyKey: idempotencyKey,
+	}, products)
+	if err != nil {
+		return "", cart, err
+	}
+
+	// The order exists at this point; a failure to empty the cart is not a
+	// reason to report the checkout as failed.
+	if err := uc.cartRepo.Clear(userID); err != nil {
+		log.Printf("Order %s created but the cart of user %s could not be emptied: %v", orderID, userID, err)
+		return orderID, cart, nil
+	}
+	return orderID, domain.Cart{UserID: userID, Items: []domain.CartItem{}}, nil
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/repository"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type CategoryUseCase struct {
+	Repo repository.CategoryRepository
+}
+
+func NewCategoryUseCase(repo repository.CategoryRepository) *CategoryUseCase {
+	return &CategoryUseCase{Repo: repo}
+}
+
+func (uc *CategoryUseCase) Create(name, parentID string) (domain.Category, error) {
+	category := domain.Category{
+		ID:       uuid.New().String(),
+		Name:     strings.TrimSpace(name),
+		ParentID: parentID,
+	}
+	if category.Name == "" {
+		return domain.Category{}, domain.ErrInvalidCategory
+	}
+	if parentID != "" {
+		if _, err := uc.Repo.FindByID(parentID); err != nil {
+			return domain.Category{}, err
+		}
+	}
+	if err := uc.Repo.Save(category); err != nil {
+		return domain.Category{}, err
+	}
+	return category, nil
+}
+
+func (uc *CategoryUseCase) GetByID(id string) (domain.Category, error) {
+	return uc.Repo.FindByID(id)
+}
+
+func (uc *CategoryUseCase) List() ([]domain.Category, error) {
+	return uc.Repo.FindAll()
+}
+
+// Update renames a category or moves it under another parent. A category
+// cannot be moved into its own subtree.
+func (uc *CategoryUseCase) Update(category domain.Category) (domain.Category, error) {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return domain.Category{}, domain.ErrInvalidCategory
+	}
+	if _, err := uc.Repo.FindByID(category.ID); err != nil {
+		return domain.Category{}, err
+	}
+
+	// Walk up from the new parent; reaching the category itself means the
+	// move would create a cycle.
+	for ancestorID := category.ParentID; ancestorID != ""; {
+		if ancestorID == category.ID {
+			return domain.Category{}, domain.ErrCategoryCycle
+		}
+		ancestor, err := uc.Repo.FindByID(ancestorID)
+		if err != nil {
+			return domain.Category{}, err
+		}
+		ancestorID = ancestor.ParentID
+	}
+
+	if err := uc.Repo.Update(category); err != nil {
+		return domain.Category{}, err
+	}
+	return category, nil
+}
+
+// Delete removes an empty category. Categories with subcategories must be
+// emptied first; products in a deleted category are left uncategorized.
+func (uc *CategoryUseCase) Delete(id string) error {
+	hasChildren, err := uc.Repo.HasChildren(id)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		return domain.ErrCategoryInUse
+	}
+	return uc.Repo.Delete(id)
+}
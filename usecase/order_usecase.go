@@ -5,80 +5,309 @@ import (
 	"FoodStore-AdvProg2/proto"
 	"FoodStore-AdvProg2/repository"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type OrderUseCase struct {
-	orderRepo     repository.OrderRepository
-	productClient proto.InventoryServiceClient
-	userClient    proto.UserServiceClient
+	orderRepo       repository.OrderRepository
+	idempotencyRepo repository.IdempotencyRepository
+	sagas           *SagaCoordinator
+	productClient   proto.InventoryServiceClient
+	userClient      proto.UserServiceClient
 }
 
-func NewOrderUseCase(orderRepo repository.OrderRepository, productClient proto.InventoryServiceClient, userClient proto.UserServiceClient) *OrderUseCase {
+func NewOrderUseCase(orderRepo repository.OrderRepository, sagaRepo repository.SagaRepository, idempotencyRepo repository.IdempotencyRepository, productClient proto.InventoryServiceClient, userClient proto.UserServiceClient) *OrderUseCase {
 	return &OrderUseCase{
-		orderRepo:     orderRepo,
-		productClient: productClient,
-		userClient:    userClient,
+		orderRepo:       orderRepo,
+		idempotencyRepo: idempotencyRepo,
+		sagas:           NewSagaCoordinator(sagaRepo),
+		productClient:   productClient,
+		userClient:      userClient,
 	}
 }
 
+// idempotencyClaimLease is how long a claimed idempotency key without an
+// order blocks retries before a retry may take it over.
+const idempotencyClaimLease = 2 * time.Minute
+
+// CreateOrder places an order. When the request carries an idempotency key,
+// a replay of the same request returns the original order ID instead of
+// creating a second order, and reusing the key for a different request fails.
+// The key is recorded against the order in the transaction that saves it.
 func (uc *OrderUseCase) CreateOrder(req domain.OrderRequest) (string, error) {
+	return uc.placeOrder(req, nil)
+}
+
+// OrderForIdempotencyKey returns the ID of the order the user created under
+// key, or "" if there is none yet.
+func (uc *OrderUseCase) OrderForIdempotencyKey(userID, key string) (string, error) {
+	if key == "" || len(key) > domain.MaxIdempotencyKeyLength {
+		return "", nil
+	}
+	return uc.idempotencyRepo.FindOrderID(userID, key)
+}
+
+// placeOrder is CreateOrder for callers that have already loaded the ordered
+// products; products is nil when they have not.
+func (uc *OrderUseCase) placeOrder(req domain.OrderRequest, products map[string]*proto.GetProductResponse) (string, error) {
+	if req.IdempotencyKey == "" {
+		return uc.createOrder(req, products)
+	}
+	if len(req.IdempotencyKey) > domain.MaxIdempotencyKeyLength {
+		return "", domain.ErrIdempotencyKeyTooLong
+	}
+
+	record, claimed, err := uc.idempotencyRepo.Claim(domain.IdempotencyRecord{
+		UserID:      req.UserID,
+		Key:         req.IdempotencyKey,
+		RequestHash: orderRequestHash(req),
+		CreatedAt:   time.Now(),
+	}, idempotencyClaimLease)
+	if err != nil {
+		return "", err
+	}
+	if !claimed {
+		switch {
+		case record.RequestHash != orderRequestHash(req):
+			return "", domain.ErrIdempotencyKeyReused
+		case record.OrderID == "":
+			return "", domain.ErrIdempotencyKeyInProgress
+		default:
+			return record.OrderID, nil
+		}
+	}
+
+	orderID, err := uc.createOrder(req, products)
+	if err != nil {
+		if rerr := uc.idempotencyRepo.Release(req.UserID, req.IdempotencyKey); rerr != nil {
+			log.Printf("Failed to release idempotency key %s: %v", req.IdempotencyKey, rerr)
+		}
+		return "", err
+	}
+	return orderID, nil
+}
+
+// orderRequestHash fingerprints the parts of an order request that decide
+// what gets ordered, independent of item order.
+func orderRequestHash(req domain.OrderRequest) string {
+	quantities := make(map[string]int, len(req.Items))
+	for _, item := range req.Items {
+		quantities[item.ProductID] += item.Quantity
+	}
+	productIDs := make([]string, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Strings(productIDs)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", req.UserID)
+	for _, productID := range productIDs {
+		fmt.Fprintf(h, "%s:%d\n", productID, quantities[productID])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// loadProducts looks up products in one call to inventory, keyed by ID.
+// Products that do not exist are missing from the map.
+func loadProducts(client proto.InventoryServiceClient, ids []string) (map[string]*proto.GetProductResponse, error) {
+	resp, err := client.GetProducts(context.Background(), &proto.GetProductsRequest{Ids: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load products: %w", err)
+	}
+	products := make(map[string]*proto.GetProductResponse, len(resp.Products))
+	for _, product := range resp.Products {
+		products[product.Id] = product
+	}
+	return products, nil
+}
+
+func (uc *OrderUseCase) createOrder(req domain.OrderRequest, products map[string]*proto.GetProductResponse) (string, error) {
+	// Users and products live in other services' databases, so they are
+	// checked through those services rather than with foreign keys.
 	_, err := uc.userClient.GetProfile(context.Background(), &proto.GetProfileRequest{UserId: req.UserID})
+	if status.Code(err) == codes.NotFound {
+		return "", domain.ErrUserNotFound
+	}
 	if err != nil {
-		return "", errors.New("invalid user")
+		return "", fmt.Errorf("failed to check user %s: %w", req.UserID, err)
+	}
+
+	if products == nil {
+		ids := make([]string, len(req.Items))
+		for i, itemReq := range req.Items {
+			ids[i] = itemReq.ProductID
+		}
+		if products, err = loadProducts(uc.productClient, ids); err != nil {
+			return "", err
+		}
 	}
 
-	var totalPrice float64
+	orderID := uuid.New().String()
+	var totalPrice domain.Money
 	items := make([]domain.OrderItem, len(req.Items))
 	for i, itemReq := range req.Items {
-		resp, err := uc.productClient.GetProduct(context.Background(), &proto.GetProductRequest{Id: itemReq.ProductID})
-		if err != nil {
-			return "", errors.New("invalid product")
+		resp, ok := products[itemReq.ProductID]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", domain.ErrProductNotFound, itemReq.ProductID)
 		}
-		if resp.Stock < int32(itemReq.Quantity) {
-			return "", errors.New("insufficient stock")
+		if resp.ArchivedAt != 0 {
+			return "", fmt.Errorf("%w: %s", domain.ErrProductArchived, itemReq.ProductID)
 		}
 
+		price := domain.MoneyFromFields(resp.PriceAmount, resp.Currency, resp.Price)
 		items[i] = domain.OrderItem{
-			ID:        uuid.New().String(),
-			OrderID:   "", 
-			ProductID: itemReq.ProductID,
-			Quantity:  itemReq.Quantity,
-			Price:     resp.Price,
+			ID:          uuid.New().String(),
+			OrderID:     orderID,
+			ProductID:   itemReq.ProductID,
+			ProductName: resp.Name,
+			Quantity:    itemReq.Quantity,
+			Price:       price,
+		}
+		totalPrice, err = totalPrice.Add(price.Multiply(itemReq.Quantity))
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", domain.ErrMixedCurrencies, err)
 		}
-		totalPrice += float64(itemReq.Quantity) * resp.Price
 	}
 
 	order := domain.Order{
-		ID:         uuid.New().String(),
-		UserID:     req.UserID,
-		TotalPrice: totalPrice,
-		Status:     "pending",
-		CreatedAt:  time.Now(),
+		ID:             orderID,
+		UserID:         req.UserID,
+		TotalPrice:     totalPrice,
+		Status:         domain.OrderStatusPending,
+		CreatedAt:      time.Now(),
+		Items:          items,
+		IdempotencyKey: req.IdempotencyKey,
 	}
 
-	orderID, err := uc.orderRepo.Save(order, items)
+	payload, err := json.Marshal(order)
 	if err != nil {
 		return "", err
 	}
 
-	for _, item := range items {
-		_, err := uc.productClient.UpdateStock(context.Background(), &proto.UpdateStockRequest{
-			Id:        item.ProductID,
-			Stock:     int32(item.Quantity),
-			Decrement: true,
-		})
-		if err != nil {
-			return "", errors.New("failed to update stock")
-		}
+	saga := &domain.Saga{
+		ID:      uuid.New().String(),
+		Type:    domain.SagaTypeCreateOrder,
+		OrderID: orderID,
+		Payload: payload,
+	}
+	if err := uc.sagas.Execute(saga, uc.createOrderSteps(order)); err != nil {
+		return "", err
 	}
 
 	return orderID, nil
 }
 
+func (uc *OrderUseCase) createOrderSteps(order domain.Order) []SagaStep {
+	return []SagaStep{
+		{
+			Name: "save_order",
+			Action: func() error {
+				_, err := uc.orderRepo.Save(order, order.Items)
+				return err
+			},
+			Compensate: func() error {
+				return uc.markOrderFailed(order.ID)
+			},
+		},
+		{
+			Name: "reserve_stock",
+			Action: func() error {
+				lines := make([]*proto.StockLine, len(order.Items))
+				for i, item := range order.Items {
+					lines[i] = &proto.StockLine{
+						ProductId: item.ProductID,
+						Quantity:  int32(item.Quantity),
+					}
+				}
+				_, err := uc.productClient.ReserveStock(context.Background(), &proto.ReserveStockRequest{
+					Reference: order.ID,
+					Items:     lines,
+				})
+				if err != nil {
+					return err
+				}
+
+				// An order cancelled before its stock was reserved had nothing
+				// to release then, so the reservation is returned here.
+				current, _, err := uc.orderRepo.FindByID(order.ID)
+				if err != nil {
+					return err
+				}
+				if domain.ReleasesStock(current.Status) {
+					return uc.releaseOrderStock(current)
+				}
+				return nil
+			},
+			Compensate: func() error {
+				_, err := uc.productClient.ReleaseStock(context.Background(), &proto.ReleaseStockRequest{Reference: order.ID})
+				return err
+			},
+		},
+	}
+}
+
+// sagaStaleAfter is how long an unfinished saga must go without progress
+// before it is taken for abandoned. Sagas that another instance is still
+// executing update their row after every step and are left alone.
+const sagaStaleAfter = 5 * time.Minute
+
+// RunSagaRecovery recovers abandoned sagas now and then every sagaStaleAfter
+// until ctx is cancelled, so sagas left by an instance that stopped are
+// picked up even if nothing restarts later.
+func (uc *OrderUseCase) RunSagaRecovery(ctx context.Context) {
+	ticker := time.NewTicker(sagaStaleAfter)
+	defer ticker.Stop()
+
+	for {
+		if err := uc.RecoverSagas(); err != nil {
+			log.Printf("Failed to recover abandoned order sagas: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RecoverSagas finishes or rolls back order sagas that have made no progress
+// for sagaStaleAfter, such as those interrupted by a restart.
+func (uc *OrderUseCase) RecoverSagas() error {
+	sagas, err := uc.sagas.repo.FindUnfinished(domain.SagaTypeCreateOrder, time.Now().Add(-sagaStaleAfter))
+	if err != nil {
+		return err
+	}
+
+	for i := range sagas {
+		saga := &sagas[i]
+		var order domain.Order
+		if err := json.Unmarshal(saga.Payload, &order); err != nil {
+			log.Printf("Skipping saga %s with unreadable payload: %v", saga.ID, err)
+			continue
+		}
+		if err := uc.sagas.Resume(saga, uc.createOrderSteps(order)); err != nil {
+			log.Printf("Failed to recover saga %s for order %s: %v", saga.ID, saga.OrderID, err)
+			continue
+		}
+		log.Printf("Recovered saga %s for order %s: %s", saga.ID, saga.OrderID, saga.Status)
+	}
+
+	return nil
+}
+
 func (uc *OrderUseCase) GetOrderByID(id string) (domain.Order, error) {
 	order, items, err := uc.orderRepo.FindByID(id)
 	if err != nil {
@@ -88,17 +317,162 @@ func (uc *OrderUseCase) GetOrderByID(id string) (domain.Order, error) {
 	return order, nil
 }
 
-func (uc *OrderUseCase) UpdateOrderStatus(orderID, status string) error {
-	return uc.orderRepo.UpdateStatus(orderID, status)
+func (uc *OrderUseCase) UpdateOrderStatus(orderID, status, actorID, reason string) error {
+	if !domain.IsValidOrderStatus(status) {
+		return fmt.Errorf("%w: %s", domain.ErrUnknownOrderStatus, status)
+	}
+	if !domain.IsSettableOrderStatus(status) {
+		return fmt.Errorf("%w: %s", domain.ErrStatusNotSettable, status)
+	}
+
+	order, _, err := uc.orderRepo.FindByID(orderID)
+	if err != nil {
+		return err
+	}
+
+	// Repeating a cancel or refund is allowed so a retried request can finish
+	// returning stock if the first attempt failed after the status changed.
+	if order.Status != status || !domain.ReleasesStock(status) {
+		if err := domain.ValidateOrderTransition(order.Status, status); err != nil {
+			return err
+		}
+		err := uc.orderRepo.UpdateStatus(domain.OrderStatusChange{
+			OrderID:   orderID,
+			OldStatus: order.Status,
+			NewStatus: status,
+			ActorID:   actorID,
+			Reason:    reason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if domain.ReleasesStock(status) {
+		return uc.releaseOrderStock(order)
+	}
+	return nil
+}
+
+// releaseOrderStock returns the order's reservation to inventory. Inventory
+// releases a reservation at most once, so calling this again is harmless.
+// The order is marked released only when a reservation was returned: one
+// cancelled before its saga reserved stock is released by the saga instead.
+func (uc *OrderUseCase) releaseOrderStock(order domain.Order) error {
+	if order.StockReleasedAt != nil {
+		return nil
+	}
+
+	resp, err := uc.productClient.ReleaseStock(context.Background(), &proto.ReleaseStockRequest{Reference: order.ID})
+	if err != nil {
+		return fmt.Errorf("failed to return stock for order %s: %w", order.ID, err)
+	}
+	if !resp.Released {
+		return nil
+	}
+
+	return uc.orderRepo.MarkStockReleased(order.ID)
+}
+
+// markOrderFailed fails an order whose creation was rolled back and frees
+// its idempotency key, so that retrying the request places a new order.
+func (uc *OrderUseCase) markOrderFailed(orderID string) error {
+	order, _, err := uc.orderRepo.FindByID(orderID)
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if order.Status != domain.OrderStatusFailed {
+		if err := domain.ValidateOrderTransition(order.Status, domain.OrderStatusFailed); err != nil {
+			return err
+		}
+		err := uc.orderRepo.UpdateStatus(domain.OrderStatusChange{
+			OrderID:   orderID,
+			OldStatus: order.Status,
+			NewStatus: domain.OrderStatusFailed,
+			Reason:    "order creation rolled back",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return uc.idempotencyRepo.ReleaseOrder(orderID)
 }
 
-func (uc *OrderUseCase) GetOrdersByUserID(userID string) ([]domain.Order, error) {
-	return uc.orderRepo.FindByUserID(userID)
+func (uc *OrderUseCase) GetOrderHistory(orderID string) ([]domain.OrderStatusChange, error) {
+	if _, _, err := uc.orderRepo.FindByID(orderID); err != nil {
+		return nil, err
+	}
+	return uc.orderRepo.FindStatusHistory(orderID)
 }
 
-func (uc *OrderUseCase) GetAllOrders() ([]domain.Order, error) {
-	return uc.orderRepo.FindAll()
+// maxOrdersPerPage bounds a single page of an order listing.
+const maxOrdersPerPage = 100
+
+// ListOrders returns one page of orders, with their items when includeItems
+// is set. Items are loaded for the whole page at once. An empty sort key
+// sorts by creation time. A cursor from an earlier page's NextCursor or
+// PrevCursor continues from there and overrides pagination.Page; the total
+// is then not counted. The cursor must come from a listing with the same
+// sort.
+func (uc *OrderUseCase) ListOrders(filter domain.OrderFilter, sort domain.SortParams, pagination domain.PaginationParams, cursor string, includeItems bool) (domain.OrderPage, error) {
+	if sort.By == "" {
+		sort.By = domain.SortByCreatedAt
+	}
+	if err := domain.ValidateOrderSort(sort); err != nil {
+		return domain.OrderPage{}, err
+	}
+	filter.MinTotal.Currency = domain.NormalizeCurrency(filter.MinTotal.Currency)
+	filter.MaxTotal.Currency = domain.NormalizeCurrency(filter.MaxTotal.Currency)
+	if err := filter.Validate(); err != nil {
+		return domain.OrderPage{}, err
+	}
+
+	if pagination.Page < 1 {
+		pagination.Page = 1
+	}
+	if pagination.PerPage < 1 {
+		pagination.PerPage = 10
+	}
+	if pagination.PerPage > maxOrdersPerPage {
+		pagination.PerPage = maxOrdersPerPage
+	}
+	offset := (pagination.Page - 1) * pagination.PerPage
+
+	var from *domain.Cursor
+	if cursor != "" {
+		decoded, err := domain.DecodeCursor(cursor, sort)
+		if err != nil {
+			return domain.OrderPage{}, err
+		}
+		if _, err := domain.OrderSortValue(decoded); err != nil {
+			return domain.OrderPage{}, domain.ErrInvalidCursor
+		}
+		from = &decoded
+	}
+	page, err := uc.orderRepo.ListOrders(filter, sort, pagination, offset, from)
+	if err != nil {
+		return domain.OrderPage{}, err
+	}
+	if includeItems {
+		orderIDs := make([]string, len(page.Orders))
+		for i, order := range page.Orders {
+			orderIDs[i] = order.ID
+		}
+		items, err := uc.orderRepo.FindItemsByOrderIDs(orderIDs)
+		if err != nil {
+			return domain.OrderPage{}, err
+		}
+		for i := range page.Orders {
+			page.Orders[i].Items = items[page.Orders[i].ID]
+		}
+	}
+
+	page.PerPage = pagination.PerPage
+	if from == nil {
+		page.Page = pagination.Page
+	}
+	return page, nil
 }
-func (uc *OrderUseCase) DeleteOrderItemsByProduct(productID string) error {
-    return uc.orderRepo.DeleteOrderItemsByProduct(productID)
-}
\ No newline at end of file
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/proto"
+	"FoodStore-AdvProg2/repository"
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// fakeOrderRepo holds one order. Its other methods are not called.
+type fakeOrderRepo struct {
+	repository.OrderRepository
+	order         domain.Order
+	stockReleased bool
+}
+
+func (r *fakeOrderRepo) FindByID(string) (domain.Order, []domain.OrderItem, error) {
+	return r.order, nil, nil
+}
+
+func (r *fakeOrderRepo) UpdateStatus(change domain.OrderStatusChange) error {
+	r.order.Status = change.NewStatus
+	return nil
+}
+
+func (r *fakeOrderRepo) MarkStockReleased(string) error {
+	r.stockReleased = true
+	return nil
+}
+
+// fakeInventory tracks whether the order's stock is reserved. Its other
+// methods are not called.
+type fakeInventory struct {
+	proto.InventoryServiceClient
+	reserved bool
+}
+
+func (c *fakeInventory) ReserveStock(context.Context, *proto.ReserveStockRequest, ...grpc.CallOption) (*proto.ReserveStockResponse, error) {
+	c.reserved = true
+	return &proto.ReserveStockResponse{Success: true}, nil
+}
+
+func (c *fakeInventory) ReleaseStock(context.Context, *proto.ReleaseStockRequest, ...grpc.CallOption) (*proto.ReleaseStockResponse, error) {
+	released := c.reserved
+	c.reserved = false
+	return &proto.ReleaseStockResponse{Success: true, Released: released}, nil
+}
+
+func TestCancelReleasesStock(t *testing.T) {
+	tests := []struct {
+		name              string
+		reservedAtCancel  bool
+		wantStockReleased bool
+	}{
+		{"reserved", true, true},
+		{"cancelled before reservation", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := &fakeOrderRepo{order: domain.Order{ID: "o1", Status: domain.OrderStatusPending}}
+			inventory := &fakeInventory{reserved: tt.reservedAtCancel}
+			uc := NewOrderUseCase(orders, &fakeSagaRepo{}, nil, inventory, nil)
+
+			if err := uc.UpdateOrderStatus("o1", domain.OrderStatusCancelled, "staff-1", ""); err != nil {
+				t.Fatalf("UpdateOrderStatus: %v", err)
+			}
+			if orders.stockReleased != tt.wantStockReleased {
+				t.Errorf("stock released = %v, want %v", orders.stockReleased, tt.wantStockReleased)
+			}
+			if inventory.reserved {
+				t.Error("stock is still reserved")
+			}
+		})
+	}
+}
+
+func TestReserveStockStepReleasesCancelledOrder(t *testing.T) {
+	tests := []struct {
+		status       string
+		wantReserved bool
+	}{
+		{domain.OrderStatusPending, true},
+		{domain.OrderStatusCancelled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			order := domain.Order{ID: "o1", Status: tt.status, Items: []domain.OrderItem{{ProductID: "p1", Quantity: 2}}}
+			orders := &fakeOrderRepo{order: order}
+			inventory := &fakeInventory{}
+			uc := NewOrderUseCase(orders, &fakeSagaRepo{}, nil, inventory, nil)
+
+			var reserve SagaStep
+			for _, step := range uc.createOrderSteps(order) {
+				if step.Name == "reserve_stock" {
+					reserve = step
+				}
+			}
+			if err := reserve.Action(); err != nil {
+				t.Fatalf("reserve_stock: %v", err)
+			}
+			if inventory.reserved != tt.wantReserved {
+				t.Errorf("reserved = %v, want %v", inventory.reserved, tt.wantReserved)
+			}
+			if orders.stockReleased == tt.wantReserved {
+				t.Errorf("stock released = %v, want %v", orders.stockReleased, !tt.wantReserved)
+			}
+		})
+	}
+}
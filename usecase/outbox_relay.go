@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/repository"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// EventSink receives order events from the outbox relay. Delivery is
+// at-least-once: an event is retried until every sink accepts it, so sinks
+// may see the same event ID more than once.
+type EventSink interface {
+	Publish(event domain.OrderEvent) error
+}
+
+type OutboxRelay struct {
+	repo      repository.OutboxRepository
+	sinks     []EventSink
+	interval  time.Duration
+	batchSize int
+	lease     time.Duration
+	maxDelay  time.Duration
+}
+
+func NewOutboxRelay(repo repository.OutboxRepository, interval time.Duration, sinks ...EventSink) *OutboxRelay {
+	return &OutboxRelay{
+		repo:      repo,
+		sinks:     sinks,
+		interval:  interval,
+		batchSize: 100,
+		lease:     30 * time.Second,
+		maxDelay:  5 * time.Minute,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled. A batch already claimed is
+// delivered before it returns.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			delivered, err := r.DeliverBatch()
+			if err != nil {
+				log.Printf("Outbox relay error: %v", err)
+			}
+			if err != nil || delivered < r.batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverBatch claims one batch of pending events and publishes it to every
+// sink, returning how many events were claimed.
+func (r *OutboxRelay) DeliverBatch() (int, error) {
+	events, err := r.repo.ClaimPending(r.batchSize, r.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if err := r.publish(event); err != nil {
+			retryAt := time.Now().Add(r.backoff(event.Attempts))
+			log.Printf("Failed to deliver event %d (%s), retrying at %s: %v", event.ID, event.Type, retryAt.Format(time.RFC3339), err)
+			if err := r.repo.MarkFailed(event.ID, retryAt, err.Error()); err != nil {
+				return len(events), err
+			}
+			continue
+		}
+		if err := r.repo.MarkDelivered(event.ID); err != nil {
+			return len(events), err
+		}
+	}
+
+	return len(events), nil
+}
+
+func (r *OutboxRelay) publish(event domain.OrderEvent) error {
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempts && delay < r.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.maxDelay {
+		delay = r.maxDelay
+	}
+	return delay
+}
+
+// ChannelSink publishes events to an in-process channel. It is meant for
+// tests and for wiring consumers inside the same binary.
+type ChannelSink struct {
+	C chan domain.OrderEvent
+}
+
+func NewChannelSink(buffer int) *ChannelSink {
+	return &ChannelSink{C: make(chan domain.OrderEvent, buffer)}
+}
+
+func (s *ChannelSink) Publish(event domain.OrderEvent) error {
+	select {
+	case s.C <- event:
+		return nil
+	default:
+		return errors.New("channel sink is full")
+	}
+}
@@ -3,51 +3,211 @@ package usecase
 import (
 	"FoodStore-AdvProg2/domain"
 	"FoodStore-AdvProg2/repository"
-	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type ProductUseCase struct {
-	Repo repository.ProductRepository
+	Repo       repository.ProductRepository
+	Categories repository.CategoryRepository
 }
 
-func NewProductUseCase(repo repository.ProductRepository) *ProductUseCase {
-	return &ProductUseCase{Repo: repo}
+func NewProductUseCase(repo repository.ProductRepository, categories repository.CategoryRepository) *ProductUseCase {
+	return &ProductUseCase{Repo: repo, Categories: categories}
 }
 
-func (uc *ProductUseCase) Create(p domain.Product) error {
-	if p.Name == "" || p.Price <= 0 || p.Stock < 0 {
-		return errors.New("invalid product data")
+// prepareProduct validates a product and normalizes its attributes: the unit
+// defaults to pieces and tags and allergens are normalized labels.
+func (uc *ProductUseCase) prepareProduct(p domain.Product) (domain.Product, error) {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" || p.Price.Amount <= 0 || p.Stock < 0 {
+		return p, domain.ErrInvalidProduct
+	}
+	if err := domain.ValidateCurrency(p.Price.Currency); err != nil {
+		return p, err
+	}
+
+	if p.Unit == "" {
+		p.Unit = domain.UnitPiece
+	}
+	if !domain.IsValidUnit(p.Unit) {
+		return p, fmt.Errorf("%w: %q", domain.ErrInvalidUnit, p.Unit)
+	}
+	if p.CategoryID != "" {
+		if _, err := uc.Categories.FindByID(p.CategoryID); err != nil {
+			return p, err
+		}
+	}
+	p.Tags = domain.NormalizeLabels(p.Tags)
+	p.Allergens = domain.NormalizeLabels(p.Allergens)
+	if err := domain.ValidateLabels(p.Tags); err != nil {
+		return p, err
+	}
+	if err := domain.ValidateLabels(p.Allergens); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// Create stores a new product and returns it as saved.
+func (uc *ProductUseCase) Create(p domain.Product) (domain.Product, error) {
+	p, err := uc.prepareProduct(p)
+	if err != nil {
+		return domain.Product{}, err
 	}
 	p.ID = uuid.New().String()
-	return uc.Repo.Save(p)
+	if err := uc.Repo.Save(p); err != nil {
+		return domain.Product{}, err
+	}
+	return p, nil
 }
 
 func (uc *ProductUseCase) GetByID(id string) (domain.Product, error) {
 	return uc.Repo.FindByID(id)
 }
 
-func (uc *ProductUseCase) Update(id string, p domain.Product) error {
-	if p.Name == "" || p.Price <= 0 || p.Stock < 0 {
-		return errors.New("invalid product data")
+func (uc *ProductUseCase) GetByIDs(ids []string) ([]domain.Product, error) {
+	return uc.Repo.FindByIDs(ids)
+}
+
+// Update replaces every attribute of a product except its archived state and
+// returns it as saved.
+func (uc *ProductUseCase) Update(id string, p domain.Product) (domain.Product, error) {
+	p, err := uc.prepareProduct(p)
+	if err != nil {
+		return domain.Product{}, err
+	}
+	if err := uc.Repo.Update(id, p); err != nil {
+		return domain.Product{}, err
 	}
-	return uc.Repo.Update(id, p)
+	return uc.Repo.FindByID(id)
 }
 
+// Delete archives the product. Its row stays so orders and reservations that
+// mention it still resolve; archiving an archived product does nothing.
 func (uc *ProductUseCase) Delete(id string) error {
-	return uc.Repo.Delete(id)
+	return uc.Repo.Archive(id, time.Now())
 }
 
-func (uc *ProductUseCase) List(filter domain.FilterParams, pagination domain.PaginationParams) ([]domain.Product, int, error) {
+func (uc *ProductUseCase) Restore(id string) error {
+	return uc.Repo.Restore(id)
+}
+
+// maxProductsPerPage bounds a single page of a product listing or search,
+// like maxOrdersPerPage does for orders.
+const maxProductsPerPage = 100
+
+// ProductPagination applies the default page and page size of product
+// listings and caps the page size.
+func ProductPagination(pagination domain.PaginationParams) domain.PaginationParams {
 	if pagination.Page < 1 {
 		pagination.Page = 1
 	}
 	if pagination.PerPage < 1 {
 		pagination.PerPage = 10
 	}
+	if pagination.PerPage > maxProductsPerPage {
+		pagination.PerPage = maxProductsPerPage
+	}
+	return pagination
+}
+
+// prepareListing applies ProductPagination, normalizes the filter's labels
+// and returns the row offset of the page.
+func prepareListing(filter domain.FilterParams, pagination domain.PaginationParams) (domain.FilterParams, domain.PaginationParams, int) {
+	pagination = ProductPagination(pagination)
 	offset := (pagination.Page - 1) * pagination.PerPage
+	filter.Tags = domain.NormalizeLabels(filter.Tags)
+	filter.ExcludeAllergens = domain.NormalizeLabels(filter.ExcludeAllergens)
+	return filter, pagination, offset
+}
+
+// List returns one page of products. A cursor from an earlier page's
+// NextCursor or PrevCursor continues from there and overrides
+// pagination.Page; the total is then not counted. The cursor must come from
+// a listing with the same sort.
+func (uc *ProductUseCase) List(filter domain.FilterParams, sort domain.SortParams, pagination domain.PaginationParams, cursor string) (domain.ProductPage, error) {
+	if err := domain.ValidateProductSort(sort); err != nil {
+		return domain.ProductPage{}, err
+	}
+	filter, pagination, offset := prepareListing(filter, pagination)
 
-	products, total, err := uc.Repo.FindAllWithFilter(filter, pagination, offset)
-	return products, total, err
-}
\ No newline at end of file
+	var from *domain.Cursor
+	if cursor != "" {
+		decoded, err := domain.DecodeCursor(cursor, sort)
+		if err != nil {
+			return domain.ProductPage{}, err
+		}
+		if _, err := domain.ProductSortValue(decoded); err != nil {
+			return domain.ProductPage{}, domain.ErrInvalidCursor
+		}
+		from = &decoded
+	}
+	return uc.Repo.FindAllWithFilter(filter, sort, pagination, offset, from)
+}
+
+// Search ranks products by how well they match text, narrowed by filter.
+func (uc *ProductUseCase) Search(text string, filter domain.FilterParams, pagination domain.PaginationParams) ([]domain.SearchResult, int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, 0, domain.ErrEmptySearch
+	}
+	filter, pagination, offset := prepareListing(filter, pagination)
+	return uc.Repo.Search(text, filter, pagination, offset)
+}
+
+func (uc *ProductUseCase) ListTags() ([]domain.TagCount, error) {
+	return uc.Repo.FindTags()
+}
+
+// AdjustStock adds quantity to a product's stock, or takes it away when
+// decrement is set. A decrement below zero fails with ErrInsufficientStock.
+func (uc *ProductUseCase) AdjustStock(id string, quantity int, decrement bool) error {
+	if quantity < 0 {
+		return fmt.Errorf("%w: quantity %d is negative", domain.ErrInvalidStockChange, quantity)
+	}
+	if decrement {
+		quantity = -quantity
+	}
+	return uc.Repo.AdjustStock(id, quantity)
+}
+
+// Lines are merged and sorted by product ID so concurrent reservations lock
+// rows in the same order.
+func (uc *ProductUseCase) ReserveStock(reference string, lines []domain.StockLine) error {
+	if reference == "" {
+		return fmt.Errorf("%w: reservation reference is required", domain.ErrInvalidStockChange)
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("%w: no items to reserve", domain.ErrInvalidStockChange)
+	}
+
+	quantities := make(map[string]int, len(lines))
+	for _, line := range lines {
+		if line.ProductID == "" || line.Quantity <= 0 {
+			return fmt.Errorf("%w: line for product %q has quantity %d", domain.ErrInvalidStockChange, line.ProductID, line.Quantity)
+		}
+		quantities[line.ProductID] += line.Quantity
+	}
+
+	merged := make([]domain.StockLine, 0, len(quantities))
+	for productID, quantity := range quantities {
+		merged = append(merged, domain.StockLine{ProductID: productID, Quantity: quantity})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].ProductID < merged[j].ProductID
+	})
+
+	return uc.Repo.ReserveStock(domain.StockReservation{Reference: reference, Lines: merged})
+}
+
+func (uc *ProductUseCase) ReleaseStock(reference string) (bool, error) {
+	if reference == "" {
+		return false, fmt.Errorf("%w: reservation reference is required", domain.ErrInvalidStockChange)
+	}
+	return uc.Repo.ReleaseStock(reference)
+}
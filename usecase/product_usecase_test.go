@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/repository"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestProductPagination(t *testing.T) {
+	tests := []struct {
+		in, want domain.PaginationParams
+	}{
+		{domain.PaginationParams{}, domain.PaginationParams{Page: 1, PerPage: 10}},
+		{domain.PaginationParams{Page: 3, PerPage: 25}, domain.PaginationParams{Page: 3, PerPage: 25}},
+		{domain.PaginationParams{Page: -1, PerPage: -5}, domain.PaginationParams{Page: 1, PerPage: 10}},
+		{domain.PaginationParams{Page: 2, PerPage: 100}, domain.PaginationParams{Page: 2, PerPage: 100}},
+		{domain.PaginationParams{Page: 2, PerPage: 5000}, domain.PaginationParams{Page: 2, PerPage: 100}},
+	}
+	for _, tt := range tests {
+		if got := ProductPagination(tt.in); got != tt.want {
+			t.Errorf("ProductPagination(%+v) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// fakeStockRepo records stock changes. Its other methods are not called.
+type fakeStockRepo struct {
+	repository.ProductRepository
+	delta       int
+	reservation domain.StockReservation
+}
+
+func (r *fakeStockRepo) AdjustStock(id string, delta int) error {
+	r.delta = delta
+	return nil
+}
+
+func (r *fakeStockRepo) ReserveStock(reservation domain.StockReservation) error {
+	r.reservation = reservation
+	return nil
+}
+
+func TestAdjustStock(t *testing.T) {
+	tests := []struct {
+		name      string
+		quantity  int
+		decrement bool
+		wantDelta int
+		wantErr   error
+	}{
+		{"increment", 5, false, 5, nil},
+		{"decrement", 5, true, -5, nil},
+		{"zero", 0, true, 0, nil},
+		{"negative", -5, false, 0, domain.ErrInvalidStockChange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeStockRepo{}
+			err := NewProductUseCase(repo, nil).AdjustStock("p1", tt.quantity, tt.decrement)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AdjustStock error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.delta != tt.wantDelta {
+				t.Errorf("delta = %d, want %d", repo.delta, tt.wantDelta)
+			}
+		})
+	}
+}
+
+func TestReserveStock(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		lines     []domain.StockLine
+		want      []domain.StockLine
+		wantErr   error
+	}{
+		{
+			name:      "merged and sorted",
+			reference: "order-1",
+			lines:     []domain.StockLine{{ProductID: "b", Quantity: 1}, {ProductID: "a", Quantity: 2}, {ProductID: "b", Quantity: 3}},
+			want:      []domain.StockLine{{ProductID: "a", Quantity: 2}, {ProductID: "b", Quantity: 4}},
+		},
+		{"no reference", "", []domain.StockLine{{ProductID: "a", Quantity: 1}}, nil, domain.ErrInvalidStockChange},
+		{"no lines", "order-1", nil, nil, domain.ErrInvalidStockChange},
+		{"no product", "order-1", []domain.StockLine{{Quantity: 1}}, nil, domain.ErrInvalidStockChange},
+		{"zero quantity", "order-1", []domain.StockLine{{ProductID: "a"}}, nil, domain.ErrInvalidStockChange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeStockRepo{}
+			err := NewProductUseCase(repo, nil).ReserveStock(tt.reference, tt.lines)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReserveStock error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(repo.reservation.Lines, tt.want) {
+				t.Errorf("reserved lines = %v, want %v", repo.reservation.Lines, tt.want)
+			}
+		})
+	}
+}
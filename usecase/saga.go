@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"FoodStore-AdvProg2/repository"
+	"log"
+	"time"
+)
+
+// SagaStep is a single forward action of a saga together with the action that
+// undoes it. Compensations may run more than once and must be idempotent.
+type SagaStep struct {
+	Name       string
+	Action     func() error
+	Compensate func() error
+}
+
+type SagaCoordinator struct {
+	repo repository.SagaRepository
+}
+
+func NewSagaCoordinator(repo repository.SagaRepository) *SagaCoordinator {
+	return &SagaCoordinator{repo: repo}
+}
+
+// Execute persists the saga and runs its steps in order. If a step fails, every
+// step that may have taken effect is compensated in reverse order.
+func (c *SagaCoordinator) Execute(saga *domain.Saga, steps []SagaStep) error {
+	now := time.Now()
+	saga.Status = domain.SagaStatusRunning
+	saga.CompletedSteps = []string{}
+	saga.CreatedAt = now
+	saga.UpdatedAt = now
+	if err := c.repo.Create(*saga); err != nil {
+		return err
+	}
+
+	for i, step := range steps {
+		err := step.Action()
+		if err == nil {
+			saga.CompletedSteps = append(saga.CompletedSteps, step.Name)
+			err = c.repo.Update(*saga)
+		}
+		if err != nil {
+			saga.LastError = err.Error()
+			if cerr := c.compensate(saga, steps[:i+1]); cerr != nil {
+				log.Printf("Saga %s compensation incomplete: %v", saga.ID, cerr)
+			}
+			return err
+		}
+	}
+
+	saga.Status = domain.SagaStatusCompleted
+	if err := c.repo.Update(*saga); err != nil {
+		log.Printf("Saga %s finished but could not be marked completed: %v", saga.ID, err)
+	}
+	return nil
+}
+
+// Resume finishes or rolls back a saga left unfinished by a previous process.
+// A running saga whose steps all completed is marked completed; otherwise the
+// completed steps and the one that was in flight are compensated.
+func (c *SagaCoordinator) Resume(saga *domain.Saga, steps []SagaStep) error {
+	if saga.Status == domain.SagaStatusRunning && len(saga.CompletedSteps) >= len(steps) {
+		saga.Status = domain.SagaStatusCompleted
+		return c.repo.Update(*saga)
+	}
+
+	inFlight := len(saga.CompletedSteps) + 1
+	if inFlight > len(steps) {
+		inFlight = len(steps)
+	}
+	return c.compensate(saga, steps[:inFlight])
+}
+
+func (c *SagaCoordinator) compensate(saga *domain.Saga, steps []SagaStep) error {
+	saga.Status = domain.SagaStatusCompensating
+	if err := c.repo.Update(*saga); err != nil {
+		return err
+	}
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].Compensate == nil {
+			continue
+		}
+		if err := steps[i].Compensate(); err != nil {
+			saga.LastError = err.Error()
+			if uerr := c.repo.Update(*saga); uerr != nil {
+				log.Printf("Failed to record saga %s error: %v", saga.ID, uerr)
+			}
+			return err
+		}
+	}
+
+	saga.Status = domain.SagaStatusCompensated
+	return c.repo.Update(*saga)
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"FoodStore-AdvProg2/domain"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeSagaRepo accepts every write; the tests check the saga itself. It
+// records the cutoff FindUnfinished was called with.
+type fakeSagaRepo struct {
+	updatedBefore time.Time
+}
+
+func (r *fakeSagaRepo) Create(domain.Saga) error { return nil }
+
+func (r *fakeSagaRepo) Update(domain.Saga) error { return nil }
+
+func (r *fakeSagaRepo) FindUnfinished(_ string, updatedBefore time.Time) ([]domain.Saga, error) {
+	r.updatedBefore = updatedBefore
+	return []domain.Saga{{ID: "saga-1", Status: domain.SagaStatusRunning, Payload: []byte("not json")}}, nil
+}
+
+// sagaSteps returns steps named a, b, c and d that record their actions and
+// compensations in calls. The step named failing fails its action.
+func sagaSteps(calls *[]string, failing string) []SagaStep {
+	var steps []SagaStep
+	for _, name := range []string{"a", "b", "c", "d"} {
+		name := name
+		steps = append(steps, SagaStep{
+			Name: name,
+			Action: func() error {
+				*calls = append(*calls, "do "+name)
+				if name == failing {
+					return errors.New(name + " failed")
+				}
+				return nil
+			},
+			Compensate: func() error {
+				*calls = append(*calls, "undo "+name)
+				return nil
+			},
+		})
+	}
+	return steps
+}
+
+func TestSagaExecuteCompensatesInReverse(t *testing.T) {
+	tests := []struct {
+		name       string
+		failing    string
+		wantCalls  []string
+		wantStatus string
+		wantSteps  []string
+	}{
+		{
+			name:       "all succeed",
+			wantCalls:  []string{"do a", "do b", "do c", "do d"},
+			wantStatus: domain.SagaStatusCompleted,
+			wantSteps:  []string{"a", "b", "c", "d"},
+		},
+		{
+			name:       "first fails",
+			failing:    "a",
+			wantCalls:  []string{"do a", "undo a"},
+			wantStatus: domain.SagaStatusCompensated,
+			wantSteps:  []string{},
+		},
+		{
+			name:       "third fails",
+			failing:    "c",
+			wantCalls:  []string{"do a", "do b", "do c", "undo c", "undo b", "undo a"},
+			wantStatus: domain.SagaStatusCompensated,
+			wantSteps:  []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			saga := &domain.Saga{ID: "saga-1"}
+			err := NewSagaCoordinator(&fakeSagaRepo{}).Execute(saga, sagaSteps(&calls, tt.failing))
+			if (err != nil) != (tt.failing != "") {
+				t.Fatalf("Execute error = %v", err)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+			if saga.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", saga.Status, tt.wantStatus)
+			}
+			if !reflect.DeepEqual(saga.CompletedSteps, tt.wantSteps) {
+				t.Errorf("completed steps = %v, want %v", saga.CompletedSteps, tt.wantSteps)
+			}
+		})
+	}
+}
+
+func TestSagaExecuteStopsAtFailedCompensation(t *testing.T) {
+	var calls []string
+	steps := sagaSteps(&calls, "c")
+	steps[1].Compensate = func() error {
+		calls = append(calls, "undo b")
+		return errors.New("undo b failed")
+	}
+	repo := &fakeSagaRepo{}
+	saga := &domain.Saga{ID: "saga-1"}
+	if err := NewSagaCoordinator(repo).Execute(saga, steps); err == nil {
+		t.Fatal("Execute error = nil, want the failed step's error")
+	}
+
+	wantCalls := []string{"do a", "do b", "do c", "undo c", "undo b"}
+	if !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("calls = %v, want %v", calls, wantCalls)
+	}
+	if saga.Status != domain.SagaStatusCompensating {
+		t.Errorf("status = %s, want %s so recovery retries it", saga.Status, domain.SagaStatusCompensating)
+	}
+}
+
+func TestSagaResume(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		completed  []string
+		wantCalls  []string
+		wantStatus string
+	}{
+		{
+			name:       "nothing completed",
+			status:     domain.SagaStatusRunning,
+			completed:  []string{},
+			wantCalls:  []string{"undo a"},
+			wantStatus: domain.SagaStatusCompensated,
+		},
+		{
+			name:       "in flight step is compensated",
+			status:     domain.SagaStatusRunning,
+			completed:  []string{"a", "b"},
+			wantCalls:  []string{"undo c", "undo b", "undo a"},
+			wantStatus: domain.SagaStatusCompensated,
+		},
+		{
+			name:       "compensating saga is compensated again",
+			status:     domain.SagaStatusCompensating,
+			completed:  []string{"a", "b", "c", "d"},
+			wantCalls:  []string{"undo d", "undo c", "undo b", "undo a"},
+			wantStatus: domain.SagaStatusCompensated,
+		},
+		{
+			name:       "running saga with every step done completes",
+			status:     domain.SagaStatusRunning,
+			completed:  []string{"a", "b", "c", "d"},
+			wantCalls:  nil,
+			wantStatus: domain.SagaStatusCompleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			saga := &domain.Saga{ID: "saga-1", Status: tt.status, CompletedSteps: tt.completed}
+			if err := NewSagaCoordinator(&fakeSagaRepo{}).Resume(saga, sagaSteps(&calls, "")); err != nil {
+				t.Fatalf("Resume: %v", err)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tt.wantCalls)
+			}
+			if saga.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", saga.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRecoverSagasSkipsRecentSagas(t *testing.T) {
+	repo := &fakeSagaRepo{}
+	uc := NewOrderUseCase(nil, repo, nil, nil, nil)
+
+	before := time.Now()
+	if err := uc.RecoverSagas(); err != nil {
+		t.Fatalf("RecoverSagas: %v", err)
+	}
+	after := time.Now()
+
+	if repo.updatedBefore.Before(before.Add(-sagaStaleAfter)) || repo.updatedBefore.After(after.Add(-sagaStaleAfter)) {
+		t.Errorf("FindUnfinished cutoff = %s, want %s before now", repo.updatedBefore, sagaStaleAfter)
+	}
+}
@@ -3,19 +3,37 @@ package usecase
 import (
 	"FoodStore-AdvProg2/domain"
 	"FoodStore-AdvProg2/repository"
+	"context"
 	"errors"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// AccessTokenSigner issues and checks the signed access tokens handed out at
+// login and refresh.
+type AccessTokenSigner interface {
+	Sign(claims domain.AccessClaims) (string, error)
+	Verify(token string) (domain.AccessClaims, error)
+	PublicKeys() []domain.SigningKey
+}
+
 type UserUseCase struct {
-	UserRepo repository.UserRepository
+	UserRepo        repository.UserRepository
+	Signer          AccessTokenSigner
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
 }
 
-func NewUserUseCase(userRepo repository.UserRepository) *UserUseCase {
-	return &UserUseCase{UserRepo: userRepo}
+func NewUserUseCase(userRepo repository.UserRepository, signer AccessTokenSigner, accessTokenTTL, refreshTokenTTL time.Duration) *UserUseCase {
+	return &UserUseCase{
+		UserRepo:        userRepo,
+		Signer:          signer,
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+	}
 }
 
 func (uc *UserUseCase) Register(user domain.User) (string, error) {
@@ -29,6 +47,7 @@ func (uc *UserUseCase) Register(user domain.User) (string, error) {
 	}
 
 	user.Password = string(hashedPassword)
+	user.Role = domain.RoleCustomer
 	user.CreatedAt = time.Now()
 
 	userID, err := uc.UserRepo.Save(user)
@@ -39,28 +58,71 @@ func (uc *UserUseCase) Register(user domain.User) (string, error) {
 	return userID, nil
 }
 
-func (uc *UserUseCase) Authenticate(username, password string) (string, string, error) {
+func (uc *UserUseCase) Authenticate(username, password string) (domain.Session, error) {
 	user, err := uc.UserRepo.FindByUsername(username)
 	if err != nil {
-		return "", "", errors.New("invalid username or password")
+		return domain.Session{}, errors.New("invalid username or password")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", "", errors.New("invalid username or password")
+		return domain.Session{}, errors.New("invalid username or password")
+	}
+
+	return uc.startSession(user)
+}
+
+// Refresh trades a refresh token for a new session. The refresh token is
+// single-use: it is revoked here and the new session carries a fresh one.
+func (uc *UserUseCase) Refresh(refreshToken string) (domain.Session, error) {
+	user, token, err := uc.UserRepo.FindUserByToken(refreshToken)
+	if err != nil {
+		return domain.Session{}, domain.ErrInvalidToken
+	}
+	if !time.Now().Before(token.ExpiresAt) {
+		return domain.Session{}, domain.ErrTokenExpired
 	}
 
-	token := uuid.New().String()
-	tokenData := domain.Token{
+	// Only one of two concurrent refreshes with the same token gets to delete it.
+	deleted, err := uc.UserRepo.DeleteToken(refreshToken)
+	if err != nil {
+		return domain.Session{}, err
+	}
+	if !deleted {
+		return domain.Session{}, domain.ErrInvalidToken
+	}
+
+	return uc.startSession(user)
+}
+
+func (uc *UserUseCase) startSession(user domain.User) (domain.Session, error) {
+	now := time.Now()
+	accessToken, err := uc.Signer.Sign(domain.AccessClaims{
 		UserID:    user.ID,
-		Token:     token,
-		CreatedAt: time.Now(),
+		Role:      user.Role,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(uc.AccessTokenTTL),
+	})
+	if err != nil {
+		return domain.Session{}, err
 	}
 
-	if err := uc.UserRepo.SaveToken(tokenData); err != nil {
-		return "", "", err
+	refreshToken := domain.Token{
+		UserID:    user.ID,
+		Token:     uuid.New().String(),
+		CreatedAt: now,
+		ExpiresAt: now.Add(uc.RefreshTokenTTL),
+	}
+	if err := uc.UserRepo.SaveToken(refreshToken); err != nil {
+		return domain.Session{}, err
 	}
 
-	return token, user.ID, nil
+	return domain.Session{
+		UserID:           user.ID,
+		AccessToken:      accessToken,
+		AccessExpiresAt:  now.Add(uc.AccessTokenTTL),
+		RefreshToken:     refreshToken.Token,
+		RefreshExpiresAt: refreshToken.ExpiresAt,
+	}, nil
 }
 
 func (uc *UserUseCase) GetProfile(userID string) (domain.User, error) {
@@ -73,10 +135,71 @@ func (uc *UserUseCase) GetProfile(userID string) (domain.User, error) {
 	return user, nil
 }
 
-func (uc *UserUseCase) ValidateToken(token string) (string, error) {
-	userID, err := uc.UserRepo.FindUserIDByToken(token)
+// ValidateToken checks an access token's signature and expiry. It does not
+// touch the database, so a token stays valid until it expires even if the
+// session it came from was revoked.
+func (uc *UserUseCase) ValidateToken(token string) (domain.User, error) {
+	claims, err := uc.Signer.Verify(token)
 	if err != nil {
-		return "", errors.New("invalid token")
+		return domain.User{}, err
 	}
-	return userID, nil
+	return domain.User{ID: claims.UserID, Role: claims.Role}, nil
+}
+
+func (uc *UserUseCase) SigningKeys() []domain.SigningKey {
+	return uc.Signer.PublicKeys()
+}
+
+// RevokeToken ends one of the user's sessions by revoking its refresh token.
+// Revoking a token that is already gone is not an error, so logging out twice
+// behaves like logging out once. Another user's token is left alone, as if it
+// did not exist.
+func (uc *UserUseCase) RevokeToken(userID, token string) error {
+	if userID == "" || token == "" {
+		return domain.ErrInvalidToken
+	}
+	_, err := uc.UserRepo.DeleteUserToken(userID, token)
+	return err
+}
+
+// RevokeAllTokens revokes every refresh token of a user and returns how many
+// were revoked.
+func (uc *UserUseCase) RevokeAllTokens(userID string) (int64, error) {
+	if _, err := uc.UserRepo.FindByID(userID); err != nil {
+		return 0, err
+	}
+	return uc.UserRepo.DeleteTokensByUserID(userID)
+}
+
+func (uc *UserUseCase) SweepExpiredTokens() (int64, error) {
+	return uc.UserRepo.DeleteExpiredTokens(time.Now())
+}
+
+// RunTokenSweeper deletes expired tokens every interval until ctx is done.
+func (uc *UserUseCase) RunTokenSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := uc.SweepExpiredTokens()
+			if err != nil {
+				log.Printf("Failed to sweep expired tokens: %v", err)
+				continue
+			}
+			if removed > 0 {
+				log.Printf("Swept %d expired tokens", removed)
+			}
+		}
+	}
+}
+
+func (uc *UserUseCase) SetRole(userID, role string) error {
+	if !domain.IsValidRole(role) {
+		return domain.ErrInvalidRole
+	}
+	return uc.UserRepo.UpdateRole(userID, role)
 }
\ No newline at end of file